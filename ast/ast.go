@@ -3,14 +3,18 @@ package ast
 import (
 	"bytes"
 	"strings"
+	"unicode/utf8"
 	"vabna/number"
 	"vabna/token"
-
 )
 
 type Node interface {
 	TokenLit() string
 	String() string
+	// Pos is the position of the first character of the node
+	Pos() token.Pos
+	// End is the position right after the last character of the node
+	End() token.Pos
 }
 
 type Stmt interface {
@@ -39,6 +43,20 @@ func (p *Program) TokenLit() string {
 
 }
 
+func (p *Program) Pos() token.Pos {
+	if len(p.Stmts) > 0 {
+		return p.Stmts[0].Pos()
+	}
+	return token.Pos{}
+}
+
+func (p *Program) End() token.Pos {
+	if len(p.Stmts) > 0 {
+		return p.Stmts[len(p.Stmts)-1].End()
+	}
+	return token.Pos{}
+}
+
 func (p *Program) String() string {
 
 	var out bytes.Buffer
@@ -58,16 +76,48 @@ type StringLit struct {
 
 func (s *StringLit) exprNode()        {}
 func (s *StringLit) TokenLit() string { return s.Token.Literal }
+func (s *StringLit) Pos() token.Pos     { return s.Token.Pos() }
+func (s *StringLit) End() token.Pos     { return tokenEnd(s.Token) }
 func (s *StringLit) String() string   { return s.Token.Literal }
 
+// InterpStringLit is a string like "বয়স {বয়স + 1}"; its parts
+// are *StringLit for the text and any expression for `{}` parts
+type InterpStringLit struct {
+	Token token.Token
+	Parts []Expr
+}
+
+func (is *InterpStringLit) exprNode()        {}
+func (is *InterpStringLit) TokenLit() string { return is.Token.Literal }
+func (is *InterpStringLit) Pos() token.Pos   { return is.Token.Pos() }
+func (is *InterpStringLit) End() token.Pos   { return tokenEnd(is.Token) }
+func (is *InterpStringLit) String() string {
+	var out bytes.Buffer
+
+	out.WriteString("\"")
+	for _, p := range is.Parts {
+		if s, ok := p.(*StringLit); ok {
+			out.WriteString(s.Value)
+		} else {
+			out.WriteString("{" + p.String() + "}")
+		}
+	}
+	out.WriteString("\"")
+
+	return out.String()
+}
+
 //Arrays
 type ArrLit struct {
 	Token token.Token
 	Elms  []Expr
+	Close token.Token // The ']' token
 }
 
 func (ar *ArrLit) exprNode()        {}
 func (ar *ArrLit) TokenLit() string { return ar.Token.Literal }
+func (ar *ArrLit) Pos() token.Pos     { return ar.Token.Pos() }
+func (ar *ArrLit) End() token.Pos     { return tokenEnd(ar.Close) }
 func (ar *ArrLit) String() string {
 	var out bytes.Buffer
 
@@ -90,10 +140,13 @@ type IndexExpr struct {
 	Token token.Token
 	Left  Expr
 	Index Expr
+	Close token.Token // The ']' token
 }
 
 func (ie *IndexExpr) exprNode()        {}
 func (ie *IndexExpr) TokenLit() string { return ie.Token.Literal }
+func (ie *IndexExpr) Pos() token.Pos     { return exprPos(ie.Left, ie.Token) }
+func (ie *IndexExpr) End() token.Pos     { return tokenEnd(ie.Close) }
 func (ie *IndexExpr) String() string {
 	var out bytes.Buffer
 	out.WriteString("(")
@@ -104,20 +157,41 @@ func (ie *IndexExpr) String() string {
 	return out.String()
 }
 
+// Member Expression -> module.name
+
+type MemberExpr struct {
+	Token token.Token // The '.' token
+	Left  Expr
+	Name  *Identifier
+}
+
+func (me *MemberExpr) exprNode()        {}
+func (me *MemberExpr) TokenLit() string { return me.Token.Literal }
+func (me *MemberExpr) Pos() token.Pos   { return exprPos(me.Left, me.Token) }
+func (me *MemberExpr) End() token.Pos   { return me.Name.End() }
+func (me *MemberExpr) String() string {
+	return "(" + me.Left.String() + "." + me.Name.String() + ")"
+}
+
 //Hash
 
 type HashLit struct {
 	Token token.Token
 	Pairs map[Expr]Expr
+	// Keys holds the keys of Pairs in the order they were written
+	Keys  []Expr
+	Close token.Token // The '}' token
 }
 
 func (hl *HashLit) exprNode()        {}
 func (hl *HashLit) TokenLit() string { return hl.Token.Literal }
+func (hl *HashLit) Pos() token.Pos     { return hl.Token.Pos() }
+func (hl *HashLit) End() token.Pos     { return tokenEnd(hl.Close) }
 func (hl *HashLit) String() string {
 	var out bytes.Buffer
 	pairs := []string{}
-	for key, value := range hl.Pairs {
-		pairs = append(pairs, key.String()+":"+value.String())
+	for _, key := range hl.Keys {
+		pairs = append(pairs, key.String()+":"+hl.Pairs[key].String())
 	}
 	out.WriteString("{")
 	out.WriteString(strings.Join(pairs, ", "))
@@ -131,12 +205,21 @@ type LetStmt struct {
 	Token token.Token
 	Name  Identifier
 	Value Expr
+	// Doc is the text of the comments right above the statement
+	Doc string
 }
 
 func (lst *LetStmt) stmtNode() {}
 func (lst *LetStmt) TokenLit() string {
 	return lst.Token.Literal
 }
+func (lst *LetStmt) Pos() token.Pos { return lst.Token.Pos() }
+func (lst *LetStmt) End() token.Pos {
+	if lst.Value != nil {
+		return lst.Value.End()
+	}
+	return lst.Name.End()
+}
 
 func (lst *LetStmt) String() string {
 	var out bytes.Buffer
@@ -154,6 +237,21 @@ func (lst *LetStmt) String() string {
 	return out.String()
 }
 
+// Import statement -> ano "path/to/module.vab";
+
+type ImportStmt struct {
+	Token token.Token
+	Path  *StringLit
+}
+
+func (is *ImportStmt) stmtNode()        {}
+func (is *ImportStmt) TokenLit() string { return is.Token.Literal }
+func (is *ImportStmt) Pos() token.Pos   { return is.Token.Pos() }
+func (is *ImportStmt) End() token.Pos   { return is.Path.End() }
+func (is *ImportStmt) String() string {
+	return is.TokenLit() + " \"" + is.Path.Value + "\";"
+}
+
 //Return statement
 
 type ReturnStmt struct {
@@ -165,6 +263,13 @@ func (r *ReturnStmt) stmtNode() {}
 func (r *ReturnStmt) TokenLit() string {
 	return r.Token.Literal
 }
+func (r *ReturnStmt) Pos() token.Pos { return r.Token.Pos() }
+func (r *ReturnStmt) End() token.Pos {
+	if r.ReturnVal != nil {
+		return r.ReturnVal.End()
+	}
+	return tokenEnd(r.Token)
+}
 
 func (r *ReturnStmt) String() string {
 	var out bytes.Buffer
@@ -177,6 +282,28 @@ func (r *ReturnStmt) String() string {
 	return out.String()
 }
 
+// Loop control statements -> thamo; chalao;
+
+type BreakStmt struct {
+	Token token.Token
+}
+
+func (b *BreakStmt) stmtNode()        {}
+func (b *BreakStmt) TokenLit() string { return b.Token.Literal }
+func (b *BreakStmt) Pos() token.Pos   { return b.Token.Pos() }
+func (b *BreakStmt) End() token.Pos   { return tokenEnd(b.Token) }
+func (b *BreakStmt) String() string   { return b.Token.Literal + ";" }
+
+type ContinueStmt struct {
+	Token token.Token
+}
+
+func (c *ContinueStmt) stmtNode()        {}
+func (c *ContinueStmt) TokenLit() string { return c.Token.Literal }
+func (c *ContinueStmt) Pos() token.Pos   { return c.Token.Pos() }
+func (c *ContinueStmt) End() token.Pos   { return tokenEnd(c.Token) }
+func (c *ContinueStmt) String() string   { return c.Token.Literal + ";" }
+
 //Expression Statment
 type ExprStmt struct {
 	Token token.Token
@@ -187,6 +314,13 @@ func (e *ExprStmt) stmtNode() {}
 func (e *ExprStmt) TokenLit() string {
 	return e.Token.Literal
 }
+func (e *ExprStmt) Pos() token.Pos { return exprPos(e.Expr, e.Token) }
+func (e *ExprStmt) End() token.Pos {
+	if e.Expr != nil {
+		return e.Expr.End()
+	}
+	return tokenEnd(e.Token)
+}
 
 func (e *ExprStmt) String() string {
 	//fmt.Println(e.Expr.TokenLit())
@@ -221,6 +355,8 @@ func (id *Identifier) exprNode() {}
 func (id *Identifier) TokenLit() string {
 	return id.Token.Literal
 }
+func (id *Identifier) Pos() token.Pos { return id.Token.Pos() }
+func (id *Identifier) End() token.Pos { return tokenEnd(id.Token) }
 
 func (id *Identifier) String() string {
 
@@ -236,6 +372,8 @@ type NumberLit struct{
 
 func (nl *NumberLit) exprNode(){}
 func (nl *NumberLit) TokenLit() string{ return nl.Token.Literal }
+func (nl *NumberLit) Pos() token.Pos { return nl.Token.Pos() }
+func (nl *NumberLit) End() token.Pos { return tokenEnd(nl.Token) }
 func (nl *NumberLit) String() string{ return nl.Token.Literal }
 
 // Prefix Expression
@@ -248,6 +386,8 @@ type PrefixExpr struct {
 
 func (pref *PrefixExpr) exprNode()        {}
 func (pref *PrefixExpr) TokenLit() string { return pref.Token.Literal }
+func (pref *PrefixExpr) Pos() token.Pos     { return pref.Token.Pos() }
+func (pref *PrefixExpr) End() token.Pos     { return exprEnd(pref.Right, pref.Token) }
 func (pref *PrefixExpr) String() string {
 
 	var out bytes.Buffer
@@ -270,6 +410,8 @@ type InfixExpr struct {
 
 func (inf *InfixExpr) exprNode()        {}
 func (inf *InfixExpr) TokenLit() string { return inf.Token.Literal }
+func (inf *InfixExpr) Pos() token.Pos     { return exprPos(inf.Left, inf.Token) }
+func (inf *InfixExpr) End() token.Pos     { return exprEnd(inf.Right, inf.Token) }
 func (inf *InfixExpr) String() string {
 
 	var out bytes.Buffer
@@ -282,6 +424,31 @@ func (inf *InfixExpr) String() string {
 
 }
 
+// Assignment Expression -> x = 1, x += 1
+
+type AssignExpr struct {
+	Token  token.Token // The assignment operator token
+	Target Expr
+	// Op is the arithmetic operator of compound assignments
+	// like `+=`; empty for plain assignments
+	Op    string
+	Value Expr
+}
+
+func (as *AssignExpr) exprNode()        {}
+func (as *AssignExpr) TokenLit() string { return as.Token.Literal }
+func (as *AssignExpr) Pos() token.Pos   { return exprPos(as.Target, as.Token) }
+func (as *AssignExpr) End() token.Pos   { return exprEnd(as.Value, as.Token) }
+func (as *AssignExpr) String() string {
+	var out bytes.Buffer
+	out.WriteString("(")
+	out.WriteString(as.Target.String())
+	out.WriteString(" " + as.Token.Literal + " ")
+	out.WriteString(as.Value.String())
+	out.WriteString(")")
+	return out.String()
+}
+
 //Boolean Expression
 type Boolean struct {
 	Token token.Token
@@ -290,15 +457,20 @@ type Boolean struct {
 
 func (b *Boolean) exprNode()        {}
 func (b *Boolean) TokenLit() string { return b.Token.Literal }
+func (b *Boolean) Pos() token.Pos     { return b.Token.Pos() }
+func (b *Boolean) End() token.Pos     { return tokenEnd(b.Token) }
 func (b *Boolean) String() string   { return b.Token.Literal }
 
 type BlockStmt struct {
 	Token token.Token
 	Stmts []Stmt
+	Close token.Token // The '}' token
 }
 
 func (bs *BlockStmt) stmtNode()        {}
 func (bs *BlockStmt) TokenLit() string { return bs.Token.Literal }
+func (bs *BlockStmt) Pos() token.Pos     { return bs.Token.Pos() }
+func (bs *BlockStmt) End() token.Pos     { return tokenEnd(bs.Close) }
 func (bs *BlockStmt) String() string {
 	var out bytes.Buffer
 	for _, s := range bs.Stmts {
@@ -316,6 +488,13 @@ type IfExpr struct {
 
 func (i *IfExpr) exprNode()        {}
 func (i *IfExpr) TokenLit() string { return i.Token.Literal }
+func (i *IfExpr) Pos() token.Pos     { return i.Token.Pos() }
+func (i *IfExpr) End() token.Pos {
+	if i.ElseBlock != nil {
+		return i.ElseBlock.End()
+	}
+	return i.TrueBlock.End()
+}
 func (i *IfExpr) String() string {
 
 	var out bytes.Buffer
@@ -340,6 +519,8 @@ type WhileExpr struct{
 
 func (w *WhileExpr) exprNode() {}
 func (w *WhileExpr) TokenLit() string { return w.Token.Literal }
+func (w *WhileExpr) Pos() token.Pos { return w.Token.Pos() }
+func (w *WhileExpr) End() token.Pos { return w.StmtBlock.End() }
 func (w *WhileExpr) String() string{
     var out bytes.Buffer
     out.WriteString("while")
@@ -350,14 +531,49 @@ func (w *WhileExpr) String() string{
     return out.String()
 }
 
+// For each loop -> protiti (x moddhe arr) { ... }
+
+type ForEachExpr struct {
+	Token token.Token
+	// Vars are the loop variables; either `value` or `key, value`
+	Vars      []*Identifier
+	Iter      Expr
+	StmtBlock *BlockStmt
+}
+
+func (fe *ForEachExpr) exprNode()        {}
+func (fe *ForEachExpr) TokenLit() string { return fe.Token.Literal }
+func (fe *ForEachExpr) Pos() token.Pos   { return fe.Token.Pos() }
+func (fe *ForEachExpr) End() token.Pos   { return fe.StmtBlock.End() }
+func (fe *ForEachExpr) String() string {
+	var out bytes.Buffer
+
+	vars := []string{}
+	for _, v := range fe.Vars {
+		vars = append(vars, v.String())
+	}
+	out.WriteString("for(")
+	out.WriteString(strings.Join(vars, ", "))
+	out.WriteString(" in ")
+	out.WriteString(fe.Iter.String())
+	out.WriteString(") ")
+	out.WriteString(fe.StmtBlock.String())
+
+	return out.String()
+}
+
 type FunctionLit struct {
 	Token  token.Token // The 'fn' token
+	Open   token.Token // The '(' before the parameters
 	Params []*Identifier
+	Close  token.Token // The ')' after the parameters
 	Body   *BlockStmt
 }
 
 func (fl *FunctionLit) exprNode()        {}
 func (fl *FunctionLit) TokenLit() string { return fl.Token.Literal }
+func (fl *FunctionLit) Pos() token.Pos     { return fl.Token.Pos() }
+func (fl *FunctionLit) End() token.Pos     { return fl.Body.End() }
 func (fl *FunctionLit) String() string {
 	var out bytes.Buffer
 
@@ -377,11 +593,14 @@ type CallExpr struct {
 	Token token.Token // The '(' token
 	Func  Expr
 	// Identifier or FunctionLiteral
-	Args []Expr
+	Args  []Expr
+	Close token.Token // The ')' token
 }
 
 func (ce *CallExpr) exprNode()        {}
 func (ce *CallExpr) TokenLit() string { return ce.Token.Literal }
+func (ce *CallExpr) Pos() token.Pos     { return exprPos(ce.Func, ce.Token) }
+func (ce *CallExpr) End() token.Pos     { return tokenEnd(ce.Close) }
 func (ce *CallExpr) String() string {
 	var out bytes.Buffer
 	args := []string{}
@@ -395,3 +614,34 @@ func (ce *CallExpr) String() string {
 	out.WriteString(")")
 	return out.String()
 }
+
+// tokenEnd returns the position right after the token `t`; tokens
+// which the lexer did not read end after their literal
+func tokenEnd(t token.Token) token.Pos {
+	if t.End.IsValid() {
+		return t.End
+	}
+
+	n := utf8.RuneCountInString(t.Literal)
+	if t.Type == token.STRING || t.Type == token.ISTRING {
+		// the quotes are not part of the literal
+		n += 2
+	}
+	return t.Pos().Advance(n)
+}
+
+// exprPos is the position of `e`, falling back to the token `t`
+// when the parser could not build the expression
+func exprPos(e Expr, t token.Token) token.Pos {
+	if e == nil {
+		return t.Pos()
+	}
+	return e.Pos()
+}
+
+func exprEnd(e Expr, t token.Token) token.Pos {
+	if e == nil {
+		return tokenEnd(t)
+	}
+	return e.End()
+}
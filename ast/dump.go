@@ -0,0 +1,63 @@
+package ast
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var nodeType = reflect.TypeOf((*Node)(nil)).Elem()
+
+// Dump returns the tree of `node` one node a line, with the type,
+// position and token of each node and its children indented below
+// it, named by the field of the parent which holds them
+func Dump(node Node) string {
+	var b strings.Builder
+	dump(&b, "", "", reflect.ValueOf(node))
+	return b.String()
+}
+
+func dump(b *strings.Builder, indent string, label string, v reflect.Value) {
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() || v.Kind() == reflect.Ptr && v.IsNil() {
+		fmt.Fprintf(b, "%s%snil\n", indent, label)
+		return
+	}
+
+	node := v.Interface().(Node)
+	s := v.Elem()
+	fmt.Fprintf(b, "%s%s%s %s", indent, label, s.Type().Name(), node.Pos())
+	if lit := node.TokenLit(); lit != "" {
+		fmt.Fprintf(b, " `%s`", lit)
+	}
+	b.WriteString("\n")
+
+	indent += "  "
+
+	if hl, ok := node.(*HashLit); ok {
+		for _, k := range hl.Keys {
+			dump(b, indent, "Key: ", reflect.ValueOf(k))
+			dump(b, indent+"  ", "Value: ", reflect.ValueOf(hl.Pairs[k]))
+		}
+		return
+	}
+
+	for i := 0; i < s.NumField(); i++ {
+		name, fv := s.Type().Field(i).Name, s.Field(i)
+
+		switch {
+		case fv.Type().Implements(nodeType):
+			dump(b, indent, name+": ", fv)
+		case fv.Kind() == reflect.Struct && fv.Addr().Type().Implements(nodeType):
+			// like the Name of a LetStmt
+			dump(b, indent, name+": ", fv.Addr())
+		case fv.Kind() == reflect.Slice && fv.Type().Elem().Implements(nodeType) && fv.Len() > 0:
+			fmt.Fprintf(b, "%s%s:\n", indent, name)
+			for j := 0; j < fv.Len(); j++ {
+				dump(b, indent+"  ", "", fv.Index(j))
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package errs
+
+// bnMessages are the Bengali messages, by code
+var bnMessages = map[string]string{
+	NO_EKTI_BEFORE_FN:   "`কাজ`-এর আগে 'ekti' বা 'একটি' পাওয়া উচিত ছিল %s",
+	EXPECTED_GOT:        "এখানে `%s` পাওয়া উচিত ছিল কিন্তু `%s` পাওয়া গেল",
+	NO_PREFIX_SUFFIX_FN: "এটা %s নিয়ে কী করা উচিত আমি জানিনা",
+	INT_PARSE_ERR:       "%s - এই এটা তো একটা সংখ্যা নয়",
+	INVALID_ASSIGN:      "`%s`-এর বাঁদিকে একটি নাম থাকা উচিত ছিল",
+	OUTSIDE_LOOP:        "`%s` শুধু লুপের ভেতরেই লেখা যায়",
+	ILLEGAL_TOKEN:       "`%s` - এই চিহ্নটা আমি চিনি না",
+	UNCLOSED_STRING:     "%s-এ শুরু হওয়া স্ট্রিংটি শেষ হয়নি",
+	UNCLOSED_COMMENT:    "%s-এ শুরু হওয়া মন্তব্যটি শেষ হয়নি",
+	UNKNOWN_ID:          "`%s` নামে কিছু নেই",
+	TYPE_MISMATCH:       "%s আর %s-এর মধ্যে `%s` চলে না",
+	UNKNOWN_OP:          "%s আর %s-এর সাথে `%s` ব্যবহার করা যায় না",
+	UNKNOWN_PREFIX_OP:   "%s-এর আগে `%s` ব্যবহার করা যায় না",
+	NOT_A_FUNCTION:      "%s কোনো কাজ নয়, তাই ডাকা যায় না",
+	WRONG_ARG_COUNT:     "কাজটির %d-টি মান দরকার কিন্তু পাওয়া গেল %d-টি",
+	NOT_HASHABLE:        "%s ডিকশনারির চাবি হিসেবে ব্যবহার করা যায় না",
+	INDEX_UNSUPPORTED:   "%s-এর ভেতরে [] দিয়ে খোঁজা যায় না",
+	BAD_ARRAY_INDEX:     "অ্যারের সূচক একটি পূর্ণসংখ্যা হওয়া উচিত, পাওয়া গেল %s",
+	INDEX_OUT_OF_RANGE:  "%s সূচকটি অ্যারের বাইরে; অ্যারেতে %d-টি জিনিস আছে",
+	KEY_NOT_FOUND:       "ডিকশনারিতে %s চাবিটি নেই",
+	INDEX_ASSIGN:        "%s-এর ভেতরে [] দিয়ে মান বসানো যায় না",
+	BAD_ASSIGN_TARGET:   "%s-এ মান বসানো যায় না",
+	UNDECLARED:          "`%s`-এ মান বসানো যায় না; এটি কখনো `%s` দিয়ে তৈরি করা হয়নি",
+	NOT_ITERABLE:        "%s-এর উপর লুপ চালানো যায় না",
+	DIV_BY_ZERO:         "শূন্য দিয়ে ভাগ করা যায় না",
+	NOT_A_NUMBER:        "`%s`-এর ফল কোনো বাস্তব সংখ্যা নয়",
+	INFINITE:            "`%s`-এর ফল অসীম",
+	NUM_OVERFLOW:        "`%s`-এর ফল খুব বড়; %d বিটের বেশি সংখ্যা রাখা যায় না",
+	NUM_UNKNOWN_OP:      "সংখ্যার সাথে `%s` ব্যবহার করা যায় না",
+	NUM_ERROR:           "সংখ্যার হিসেবে ভুল: %s",
+	ARG_COUNT:           "%d-টি মান দরকার কিন্তু পাওয়া গেল %d-টি",
+	ARG_RANGE:           "%d থেকে %d-টি মান দরকার কিন্তু পাওয়া গেল %d-টি",
+	ARG_TYPE:            "এই কাজটি %s-এর সাথে ব্যবহার করা যায় না",
+	RANGE_NOT_INT:       "পরিসরের জন্য পূর্ণসংখ্যা দরকার, পাওয়া গেল %s",
+	RANGE_TOO_LARGE:     "পরিসরের মান %s খুব বড়",
+	RANGE_ZERO_STEP:     "পরিসরের ধাপ শূন্য হতে পারে না",
+	UNKNOWN_DIGITS:      "`%s` অঙ্ক চিনি না; \"bn\" বা \"en\" লেখো",
+	DECIMAL_PLACES:      "দশমিকের পরের ঘর ০ থেকে ১০০-এর মধ্যে একটি পূর্ণসংখ্যা হওয়া উচিত, পাওয়া গেল %s",
+	BAD_MODULE_NAME:     "`%s` আনা যায় না; `%s` মডিউলের নাম হতে পারে না",
+	IMPORT_FAILED:       "`%s` আনা যায় না%s",
+	CIRCULAR_IMPORT:     "মডিউলগুলো একে অপরকে আনছে: %s",
+	MODULE_HAS_ERRORS:   "`%s` আনা যায় না; এতে ভুল আছে:\n\t%s",
+	NOT_A_MODULE:        "`.` শুধু মডিউলের সাথে ব্যবহার করা যায়, %s-এর সাথে নয়",
+	NO_MEMBER:           "%s মডিউলে `%s` নেই",
+	TRACEBACK:           "ডাকের ক্রম (সর্বশেষ ডাক শেষে):",
+	ANONYMOUS_FN:        "<নামহীন কাজ>",
+	ERROR:               "ভুল",
+
+	CLI_USAGE: `ব্যবহার:
+  vabna [অপশন] run ফাইল.vab [আর্গুমেন্ট...]   ফাইলের প্রোগ্রাম চালাও; ফাইল "-" হলে stdin থেকে পড়ো
+  vabna [অপশন] ফাইল.vab [আর্গুমেন্ট...]       run-এর ছোট রূপ
+  vabna [অপশন] -e 'কোড' [আর্গুমেন্ট...]       কোড চালিয়ে ফলাফল দেখাও
+  vabna repl                                 REPL চালু করো
+  vabna check ফাইল.vab...                     শুধু ভুল খোঁজো, চালিও না
+  vabna fmt [-check] [-w] ফাইল.vab...          ফাইল সাজাও
+  vabna translate -to=bn|roman|en ফাইল.vab... কিওয়ার্ড অন্য ভাষায় লেখো
+কিছু না দিলে REPL চালু হয়, বা stdin টার্মিনাল না হলে সেখান থেকে প্রোগ্রাম পড়া হয়।
+ফেরত কোড: ০ ঠিক আছে, ১ চালাতে গিয়ে ভুল, ২ প্রোগ্রামে লেখার ভুল, ৩ ভুল অপশন বা ফাইল।
+
+অপশন:`,
+	CLI_UNKNOWN_CMD:   "`%s` কমান্ড বা ফাইল চিনি না; `vabna -h` দেখো",
+	CLI_NEEDS_FILE:    "`%s`-এর জন্য একটি ফাইল দরকার",
+	CLI_CANNOT_READ:   "`%s` পড়া যায় না%s",
+	CLI_CANNOT_WRITE:  "`%s`-এ লেখা যায় না%s",
+	CLI_BAD_LANG:      "`%s` ভাষায় বার্তা নেই; আছে %s",
+	CLI_BAD_LOCALE:    "`%s` লোকেল ব্যবহার করা যায় না: %s",
+	CLI_BAD_NUMERALS:  "`%s` অঙ্ক চিনি না; `bn` বা `en` লেখো",
+	CLI_BAD_SPELLING:  "`%s` বানান চিনি না; `bn`, `roman` বা `en` লেখো",
+	CLI_NOT_IN_LOCALE: "%s: `# locale: %s`-এ `%s` নেই; ফলাফল চালাতে এটা যোগ করো",
+	CLI_BAD_TRACE:     "`%s` ট্রেস করা যায় না; করা যায় %s",
+	CLI_CMD_OPTIONS:   "vabna %s-এর অপশন:",
+	CLI_FLAG_NUMERALS: "সংখ্যা ছাপার `অঙ্ক`: bn বা en",
+	CLI_FLAG_LANG:     "বার্তার `ভাষা`: bn বা en",
+	CLI_FLAG_LOCALE:   "যে লোকেল `প্যাক` ব্যবহার হবে: ভেতরের bn, roman আর en, বা JSON ফাইল",
+	CLI_FLAG_CODE:     "`কোড` চালিয়ে ফলাফল দেখাও",
+	CLI_FLAG_TRACE:    "lexer, parser আর eval-এর মধ্যে যে `ধাপ` দেওয়া আছে তার JSON ঘটনা stderr-এ লেখো",
+	CLI_FLAG_CHECK:    "শুধু না-সাজানো ফাইলগুলোর নাম লেখো, আর এমন ফাইল থাকলে ১ ফেরত দাও",
+	CLI_FLAG_WRITE:    "ফলাফল না দেখিয়ে ফাইলেই লেখো",
+	CLI_FLAG_KEYWORDS: "কিওয়ার্ডের `বানান`: bn, roman বা en; না দিলে যেমন লেখা আছে তেমনই থাকে",
+	CLI_FLAG_TO:       "কিওয়ার্ড আর বিল্টইনের `বানান`: bn, roman বা en",
+
+	FILE_NOT_FOUND:     "ফাইলটি নেই",
+	FILE_NO_PERMISSION: "ফাইলটি খোলার অনুমতি নেই",
+
+	LOCALE_NO_NAME:         "লোকেল প্যাকের নাম নেই",
+	LOCALE_BAD_FILE:        "`%s` লোকেল প্যাক পড়া যায় না%s",
+	LOCALE_NO_PACKS:        "`%s`-এ কোনো লোকেল প্যাক নেই",
+	LOCALE_BAD_SPELLING:    "%s লোকেল: `%s` নাম হিসেবে চলে না, তাই %s হতে পারে না",
+	LOCALE_CONFLICT:        "%s লোকেল: `%s` একসাথে %s আর %s হতে পারে না",
+	LOCALE_UNKNOWN_KEYWORD: "%s লোকেল: %s কিওয়ার্ড চিনি না",
+	LOCALE_UNKNOWN_BUILTIN: "%s লোকেল: %s বিল্টইন চিনি না",
+	LOCALE_UNSPELLED:       "কোনো লোকেল প্যাকে %s কিওয়ার্ডের বানান নেই",
+	LOCALE_KEYWORD:         "কিওয়ার্ড %s",
+	LOCALE_BUILTIN:         "বিল্টইন %s",
+	LOCALE_NAME_TAKEN:      "%s: `%s`-কে `%s` লেখা যায় না; প্রোগ্রামে `%[3]s` নামে একটি নাম আছে",
+
+	REPL_HELP: `কমান্ড:
+  :help           এই সাহায্য দেখাও
+  :env            সেশনে ধরা সব নাম আর তাদের মান দেখাও
+  :type কোড        কোডের মানের ধরন দেখাও
+  :ast কোড         কোডের সিনট্যাক্স ট্রি দেখাও
+  :tokens কোড      কোডের টোকেনগুলো দেখাও
+  :load ফাইল.vab   ফাইলটা এই সেশনে চালাও
+  :reset          সেশনের সব নাম ভুলে যাও
+  :time কোড        কোড চালিয়ে কত সময় লাগল দেখাও
+{, ( বা [ খোলা থাকলে লেখা পরের লাইনে চলতে থাকে; Ctrl-C দিলে বাদ যায়, Ctrl-D দিলে REPL বন্ধ হয়।`,
+	REPL_UNKNOWN_CMD: "`:%s` কমান্ড চিনি না; `:help` দেখো",
+	REPL_NEEDS_CODE:  "`:%s`-এর পরে কোড লেখো",
+	REPL_NEEDS_FILE:  "`:%s`-এর পরে ফাইলের নাম লেখো",
+	REPL_NO_NAMES:    "এখনো কোনো নাম ধরা হয়নি",
+	REPL_RESET:       "সব নাম ভুলে গেলাম",
+	REPL_TIME:        "সময়: %s",
+}
+
+var bnTypes = map[string]string{
+	"INTEGER":  "পূর্ণসংখ্যা",
+	"FLOAT":    "দশমিক সংখ্যা",
+	"NUM":      "সংখ্যা",
+	"BOOLEAN":  "সত্য/মিথ্যা",
+	"NIL":      "শূন্যমান",
+	"FUNCTION": "কাজ",
+	"STRING":   "স্ট্রিং",
+	"BUILTIN":  "বিল্টইন কাজ",
+	"ARRAY":    "অ্যারে",
+	"HASH":     "ডিকশনারি",
+	"RANGE":    "পরিসর",
+	"MODULE":   "মডিউল",
+	"ERROR":    "ভুল",
+}
@@ -0,0 +1,187 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"sort"
+)
+
+// Codes of the runtime errors
+const (
+	UNKNOWN_ID         = "R001"
+	TYPE_MISMATCH      = "R002"
+	UNKNOWN_OP         = "R003"
+	UNKNOWN_PREFIX_OP  = "R004"
+	NOT_A_FUNCTION     = "R005"
+	WRONG_ARG_COUNT    = "R006"
+	NOT_HASHABLE       = "R007"
+	INDEX_UNSUPPORTED  = "R008"
+	BAD_ARRAY_INDEX    = "R009"
+	INDEX_OUT_OF_RANGE = "R010"
+	KEY_NOT_FOUND      = "R011"
+	INDEX_ASSIGN       = "R012"
+	BAD_ASSIGN_TARGET  = "R013"
+	UNDECLARED         = "R014"
+	NOT_ITERABLE       = "R015"
+	DIV_BY_ZERO        = "R016"
+	NOT_A_NUMBER       = "R017"
+	INFINITE           = "R018"
+	NUM_OVERFLOW       = "R019"
+	NUM_UNKNOWN_OP     = "R020"
+	NUM_ERROR          = "R021"
+
+	// builtins
+	ARG_COUNT       = "R030"
+	ARG_RANGE       = "R031"
+	ARG_TYPE        = "R032"
+	RANGE_NOT_INT   = "R033"
+	RANGE_TOO_LARGE = "R034"
+	RANGE_ZERO_STEP = "R035"
+	UNKNOWN_DIGITS  = "R036"
+	DECIMAL_PLACES  = "R037"
+
+	// modules
+	BAD_MODULE_NAME   = "R040"
+	IMPORT_FAILED     = "R041"
+	CIRCULAR_IMPORT   = "R042"
+	MODULE_HAS_ERRORS = "R043"
+	NOT_A_MODULE      = "R044"
+	NO_MEMBER         = "R045"
+)
+
+// Keys of the texts which are shown with errors
+const (
+	TRACEBACK    = "traceback"
+	ANONYMOUS_FN = "anonymous-function"
+	ERROR        = "error"
+)
+
+// Keys of the messages of the command line
+const (
+	CLI_USAGE         = "cli-usage"
+	CLI_UNKNOWN_CMD   = "cli-unknown-command"
+	CLI_NEEDS_FILE    = "cli-needs-file"
+	CLI_CANNOT_READ   = "cli-cannot-read"
+	CLI_CANNOT_WRITE  = "cli-cannot-write"
+	CLI_BAD_LANG      = "cli-bad-lang"
+	CLI_BAD_LOCALE    = "cli-bad-locale"
+	CLI_BAD_NUMERALS  = "cli-bad-numerals"
+	CLI_BAD_SPELLING  = "cli-bad-spelling"
+	CLI_NOT_IN_LOCALE = "cli-not-in-locale"
+	CLI_BAD_TRACE     = "cli-bad-trace"
+	CLI_CMD_OPTIONS   = "cli-command-options"
+
+	// help of the flags
+	CLI_FLAG_NUMERALS = "cli-flag-numerals"
+	CLI_FLAG_LANG     = "cli-flag-lang"
+	CLI_FLAG_LOCALE   = "cli-flag-locale"
+	CLI_FLAG_CODE     = "cli-flag-code"
+	CLI_FLAG_TRACE    = "cli-flag-trace"
+	CLI_FLAG_CHECK    = "cli-flag-check"
+	CLI_FLAG_WRITE    = "cli-flag-write"
+	CLI_FLAG_KEYWORDS = "cli-flag-keywords"
+	CLI_FLAG_TO       = "cli-flag-to"
+)
+
+// Keys of the reasons a file cannot be used,
+// shown after the message about the file
+const (
+	FILE_NOT_FOUND     = "file-not-found"
+	FILE_NO_PERMISSION = "file-no-permission"
+)
+
+// Keys of the messages of locale packs
+const (
+	LOCALE_NO_NAME         = "locale-no-name"
+	LOCALE_BAD_FILE        = "locale-bad-file"
+	LOCALE_NO_PACKS        = "locale-no-packs"
+	LOCALE_BAD_SPELLING    = "locale-bad-spelling"
+	LOCALE_CONFLICT        = "locale-conflict"
+	LOCALE_UNKNOWN_KEYWORD = "locale-unknown-keyword"
+	LOCALE_UNKNOWN_BUILTIN = "locale-unknown-builtin"
+	LOCALE_UNSPELLED       = "locale-unspelled"
+	LOCALE_KEYWORD         = "locale-keyword"
+	LOCALE_BUILTIN         = "locale-builtin"
+	LOCALE_NAME_TAKEN      = "locale-name-taken"
+)
+
+// Keys of the messages of the REPL
+const (
+	REPL_HELP        = "repl-help"
+	REPL_UNKNOWN_CMD = "repl-unknown-command"
+	REPL_NEEDS_CODE  = "repl-needs-code"
+	REPL_NEEDS_FILE  = "repl-needs-file"
+	REPL_NO_NAMES    = "repl-no-names"
+	REPL_RESET       = "repl-reset"
+	REPL_TIME        = "repl-time"
+)
+
+// Lang is the language every message is shown in
+var Lang = "bn"
+
+var catalogs = map[string]map[string]string{
+	"bn": bnMessages,
+	"en": enMessages,
+}
+
+// typeNames are the names of the object types
+// shown in messages, by language
+var typeNames = map[string]map[string]string{
+	"bn": bnTypes,
+	"en": enTypes,
+}
+
+// SetLang makes `lang` the language of the messages
+func SetLang(lang string) error {
+	if _, ok := catalogs[lang]; !ok {
+		return fmt.Errorf("no error messages in `%s`; there are %v", lang, Langs())
+	}
+	Lang = lang
+	return nil
+}
+
+// Langs returns the languages messages can be shown in
+func Langs() []string {
+	res := []string{}
+	for lang := range catalogs {
+		res = append(res, lang)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Msg returns the message with the code `code` in Lang
+func Msg(code string) string {
+	if msg, ok := catalogs[Lang][code]; ok {
+		return msg
+	}
+	return catalogs["en"][code]
+}
+
+// Format fills the message `code` with the arguments `a`
+func Format(code string, a ...interface{}) string {
+	return fmt.Sprintf(Msg(code), a...)
+}
+
+// Reason returns why the file `err` is about could not be used, as
+// ": " and the reason in Lang, to go after a message with the path;
+// it is "" for errors with no reason in the catalogs, as their own
+// text is in English
+func Reason(err error) string {
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return ": " + Msg(FILE_NOT_FOUND)
+	case errors.Is(err, fs.ErrPermission):
+		return ": " + Msg(FILE_NO_PERMISSION)
+	}
+	return ""
+}
+
+// TypeName returns the name of the object type `typ` in Lang
+func TypeName(typ string) string {
+	if name, ok := typeNames[Lang][typ]; ok {
+		return name
+	}
+	return typ
+}
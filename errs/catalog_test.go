@@ -0,0 +1,70 @@
+package errs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"testing"
+)
+
+// verbRe matches formatting verbs like %s and %[2]d
+var verbRe = regexp.MustCompile(`%(\[\d+\])?[a-z]`)
+
+func TestCatalogs(t *testing.T) {
+	for lang, msgs := range catalogs {
+		for code, msg := range enMessages {
+			other, ok := msgs[code]
+			if !ok {
+				t.Fatalf("%s -> no message for %s", lang, code)
+			}
+			if len(verbRe.FindAllString(msg, -1)) != len(verbRe.FindAllString(other, -1)) {
+				t.Fatalf("%s -> message %s takes other arguments than in en: %q", lang, code, other)
+			}
+		}
+		if len(msgs) != len(enMessages) {
+			t.Fatalf("%s -> has %d messages, en has %d", lang, len(msgs), len(enMessages))
+		}
+		if len(typeNames[lang]) != len(enTypes) {
+			t.Fatalf("%s -> has %d type names, en has %d", lang, len(typeNames[lang]), len(enTypes))
+		}
+	}
+}
+
+func TestReason(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected string
+	}{
+		{&fs.PathError{Op: "open", Path: "ক.vab", Err: fs.ErrNotExist}, ": ফাইলটি নেই"},
+		{fmt.Errorf("read: %w", fs.ErrPermission), ": ফাইলটি খোলার অনুমতি নেই"},
+		{errors.New("is a directory"), ""},
+	}
+
+	for i, tt := range tests {
+		if got := Reason(tt.err); got != tt.expected {
+			t.Fatalf("tests[%d] -> Expected=%q, Got=%q", i, tt.expected, got)
+		}
+	}
+}
+
+func TestSetLang(t *testing.T) {
+	defer SetLang("bn")
+
+	if err := SetLang("fr"); err == nil {
+		t.Fatalf("Expected an error for an unknown language")
+	}
+	if Lang != "bn" {
+		t.Fatalf("Lang changed to %s", Lang)
+	}
+
+	if err := SetLang("en"); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if got := Format(DIV_BY_ZERO); got != "division by zero" {
+		t.Fatalf("Expected English message, Got=%q", got)
+	}
+	if got := TypeName("STRING"); got != "string" {
+		t.Fatalf("Expected English type name, Got=%q", got)
+	}
+}
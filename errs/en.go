@@ -0,0 +1,133 @@
+package errs
+
+// enMessages are the English messages, by code
+var enMessages = map[string]string{
+	NO_EKTI_BEFORE_FN:   "expected 'ekti' or 'একটি' before `কাজ` %s",
+	EXPECTED_GOT:        "expected `%s` here but got `%s`",
+	NO_PREFIX_SUFFIX_FN: "do not know what to do with %s here",
+	INT_PARSE_ERR:       "%s is not a number",
+	INVALID_ASSIGN:      "the left side of `%s` should be a name",
+	OUTSIDE_LOOP:        "`%s` can only be used inside a loop",
+	ILLEGAL_TOKEN:       "unknown character `%s`",
+	UNCLOSED_STRING:     "the string starting at %s is never closed",
+	UNCLOSED_COMMENT:    "the comment starting at %s is never closed",
+	UNKNOWN_ID:          "`%s` is not defined",
+	TYPE_MISMATCH:       "type mismatch: cannot use `%[3]s` with %[1]s and %[2]s",
+	UNKNOWN_OP:          "unknown operator: %[1]s %[3]s %[2]s",
+	UNKNOWN_PREFIX_OP:   "unknown operator: %[2]s%[1]s",
+	NOT_A_FUNCTION:      "%s is not a function",
+	WRONG_ARG_COUNT:     "the function wants %d arguments but got %d",
+	NOT_HASHABLE:        "%s cannot be used as a hash key",
+	INDEX_UNSUPPORTED:   "index operator is not supported for %s",
+	BAD_ARRAY_INDEX:     "array index must be an integer, got %s",
+	INDEX_OUT_OF_RANGE:  "array index %s out of range; array has %d elements",
+	KEY_NOT_FOUND:       "key %s not found in hash",
+	INDEX_ASSIGN:        "index assignment is not supported for %s",
+	BAD_ASSIGN_TARGET:   "cannot assign to %s",
+	UNDECLARED:          "cannot assign to `%s`; it was never declared with `%s`",
+	NOT_ITERABLE:        "cannot loop over %s",
+	DIV_BY_ZERO:         "division by zero",
+	NOT_A_NUMBER:        "the result of `%s` is not a real number",
+	INFINITE:            "the result of `%s` is infinite",
+	NUM_OVERFLOW:        "the result of `%s` is too large; numbers can have at most %d bits",
+	NUM_UNKNOWN_OP:      "`%s` cannot be used with numbers",
+	NUM_ERROR:           "number error: %s",
+	ARG_COUNT:           "wrong number of arguments: wanted %d but got %d",
+	ARG_RANGE:           "wrong number of arguments: wanted %d to %d but got %d",
+	ARG_TYPE:            "this function cannot be used with %s",
+	RANGE_NOT_INT:       "range needs integers, got %s",
+	RANGE_TOO_LARGE:     "range argument %s is too large",
+	RANGE_ZERO_STEP:     "range step cannot be zero",
+	UNKNOWN_DIGITS:      "unknown numerals `%s`; use \"bn\" or \"en\"",
+	DECIMAL_PLACES:      "decimal places must be an integer from 0 to 100, got %s",
+	BAD_MODULE_NAME:     "cannot import `%s`; `%s` is not a valid name for a module",
+	IMPORT_FAILED:       "cannot import `%s`%s",
+	CIRCULAR_IMPORT:     "circular import: %s",
+	MODULE_HAS_ERRORS:   "cannot import `%s`; it has errors:\n\t%s",
+	NOT_A_MODULE:        "`.` can only be used with modules, not %s",
+	NO_MEMBER:           "module %s has no `%s`",
+	TRACEBACK:           "Traceback (most recent call last):",
+	ANONYMOUS_FN:        "<anonymous function>",
+	ERROR:               "ERR",
+
+	CLI_USAGE: `Usage:
+  vabna [options] run file.vab [args...]    run the program in the file; "-" reads it from stdin
+  vabna [options] file.vab [args...]        short for run
+  vabna [options] -e 'code' [args...]       run the code and show its value
+  vabna repl                                start the REPL
+  vabna check file.vab...                   only look for syntax errors
+  vabna fmt [-check] [-w] file.vab...       format the files
+  vabna translate -to=bn|roman|en file.vab... respell the keywords and builtins
+Without a command the REPL starts, or the program is read from stdin if it is not a terminal.
+Exit codes: 0 success, 1 runtime error, 2 syntax errors, 3 bad options or files.
+
+Options:`,
+	CLI_UNKNOWN_CMD:   "unknown command or file `%s`; see `vabna -h`",
+	CLI_NEEDS_FILE:    "`%s` needs a file",
+	CLI_CANNOT_READ:   "cannot read `%s`%s",
+	CLI_CANNOT_WRITE:  "cannot write `%s`%s",
+	CLI_BAD_LANG:      "no messages in `%s`; there are %s",
+	CLI_BAD_LOCALE:    "cannot use locale `%s`: %s",
+	CLI_BAD_NUMERALS:  "unknown numerals `%s`; use `bn` or `en`",
+	CLI_BAD_SPELLING:  "unknown spelling `%s`; use `bn`, `roman` or `en`",
+	CLI_NOT_IN_LOCALE: "%s: `# locale: %s` does not list `%s`; add it to run the result",
+	CLI_BAD_TRACE:     "cannot trace `%s`; there are %s",
+	CLI_CMD_OPTIONS:   "Options of vabna %s:",
+	CLI_FLAG_NUMERALS: "`digits` to print numbers with: bn or en",
+	CLI_FLAG_LANG:     "`language` of the messages: bn or en",
+	CLI_FLAG_LOCALE:   "locale `packs` to use: the built-in bn, roman and en, or JSON files",
+	CLI_FLAG_CODE:     "run the `code` and show its value",
+	CLI_FLAG_TRACE:    "write JSON events of the `stages` lexer, parser and eval to stderr",
+	CLI_FLAG_CHECK:    "only list the files which are not formatted and exit with 1 if there are any",
+	CLI_FLAG_WRITE:    "write the result to the files instead of printing it",
+	CLI_FLAG_KEYWORDS: "`spelling` of the keywords: bn, roman or en; by default they are kept as written",
+	CLI_FLAG_TO:       "`spelling` of the keywords and builtins: bn, roman or en",
+
+	FILE_NOT_FOUND:     "no such file",
+	FILE_NO_PERMISSION: "permission denied",
+
+	LOCALE_NO_NAME:         "locale pack has no name",
+	LOCALE_BAD_FILE:        "cannot load locale pack `%s`%s",
+	LOCALE_NO_PACKS:        "no locale packs in `%s`",
+	LOCALE_BAD_SPELLING:    "locale %s: `%s` for %s is not a valid name",
+	LOCALE_CONFLICT:        "locale %s: `%s` cannot be both %s and %s",
+	LOCALE_UNKNOWN_KEYWORD: "locale %s: unknown keyword %s",
+	LOCALE_UNKNOWN_BUILTIN: "locale %s: unknown builtin %s",
+	LOCALE_UNSPELLED:       "no locale pack spells keyword %s",
+	LOCALE_KEYWORD:         "keyword %s",
+	LOCALE_BUILTIN:         "builtin %s",
+	LOCALE_NAME_TAKEN:      "%s: cannot translate `%s` to `%s`; the program has a name `%[3]s`",
+
+	REPL_HELP: `Commands:
+  :help           show this help
+  :env            list the names of the session and their values
+  :type code      show the type of the value of the code
+  :ast code       show the syntax tree of the code
+  :tokens code    show the tokens of the code
+  :load file.vab  run the file in this session
+  :reset          forget every name of the session
+  :time code      run the code and show how long it took
+An input with an open {, ( or [ goes on over more lines; Ctrl-C drops it and Ctrl-D ends the REPL.`,
+	REPL_UNKNOWN_CMD: "unknown command `:%s`; see `:help`",
+	REPL_NEEDS_CODE:  "`:%s` needs some code",
+	REPL_NEEDS_FILE:  "`:%s` needs a file name",
+	REPL_NO_NAMES:    "no names yet",
+	REPL_RESET:       "forgot every name",
+	REPL_TIME:        "time: %s",
+}
+
+var enTypes = map[string]string{
+	"INTEGER":  "integer",
+	"FLOAT":    "float",
+	"NUM":      "number",
+	"BOOLEAN":  "boolean",
+	"NIL":      "null",
+	"FUNCTION": "function",
+	"STRING":   "string",
+	"BUILTIN":  "builtin function",
+	"ARRAY":    "array",
+	"HASH":     "hash",
+	"RANGE":    "range",
+	"MODULE":   "module",
+	"ERROR":    "error",
+}
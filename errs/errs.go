@@ -2,17 +2,27 @@ package errs
 
 import (
 	"fmt"
+	"strings"
 	"vabna/token"
 )
 
+// Codes of the parser errors; codes never change once
+// released, so that they can be looked up in the docs
 const (
-	NO_EKTI_BEFORE_FN   = "NO_EKTI_BEFORE_FN"
-	EXPECTED_GOT        = "EXPECTED_GOT"
-	NO_PREFIX_SUFFIX_FN = "NO_PREFIX_SUFFIX_FN"
-	INT_PARSE_ERR       = "INT_PARSE_ERR"
+	NO_EKTI_BEFORE_FN   = "P001"
+	EXPECTED_GOT        = "P002"
+	NO_PREFIX_SUFFIX_FN = "P003"
+	INT_PARSE_ERR       = "P004"
+	INVALID_ASSIGN      = "P005"
+	OUTSIDE_LOOP        = "P006"
+	ILLEGAL_TOKEN       = "P007"
+	UNCLOSED_STRING     = "P008"
+	UNCLOSED_COMMENT    = "P009"
 )
 
 type ParserError interface {
+	// Code is the code of the error in the catalogs
+	Code() string
 	GetMsg() string
 	GetToken() token.Token
 	String() string
@@ -24,7 +34,9 @@ type PeekError struct {
 	Got      token.Token
 }
 
-func (pe *PeekError) GetMsg() string { return Errs[EXPECTED_GOT] }
+func (pe *PeekError) Code() string { return EXPECTED_GOT }
+
+func (pe *PeekError) GetMsg() string { return Msg(EXPECTED_GOT) }
 
 func (pe *PeekError) GetToken() token.Token { return pe.Got }
 
@@ -33,15 +45,18 @@ func (pe *PeekError) String() string {
 }
 
 type NoPrefixSuffixError struct {
-	Type token.TokenType
+	Type  token.TokenType
+	Token token.Token
 }
 
+func (spe *NoPrefixSuffixError) Code() string { return NO_PREFIX_SUFFIX_FN }
+
 func (spe *NoPrefixSuffixError) GetMsg() string {
-	return Errs[NO_PREFIX_SUFFIX_FN]
+	return Msg(NO_PREFIX_SUFFIX_FN)
 }
 
 func (spe *NoPrefixSuffixError) GetToken() token.Token {
-	return token.Token{}
+	return spe.Token
 }
 
 func (spe *NoPrefixSuffixError) String() string {
@@ -51,22 +66,27 @@ func (spe *NoPrefixSuffixError) String() string {
 }
 
 type NoEktiError struct {
-	Type token.TokenType
+	Type  token.TokenType
+	Token token.Token
 }
 
-func (nee *NoEktiError) GetMsg() string { return Errs[NO_EKTI_BEFORE_FN] }
+func (nee *NoEktiError) Code() string { return NO_EKTI_BEFORE_FN }
 
-func (nee *NoEktiError) GetToken() token.Token { return token.Token{} }
+func (nee *NoEktiError) GetMsg() string { return Msg(NO_EKTI_BEFORE_FN) }
+
+func (nee *NoEktiError) GetToken() token.Token { return nee.Token }
 
 func (nee *NoEktiError) String() string {
-	return fmt.Sprintf(Errs[NO_EKTI_BEFORE_FN], nee.Type)
+	return fmt.Sprintf(Msg(NO_EKTI_BEFORE_FN), nee.Type)
 }
 
 type IntegerParseError struct {
 	Token token.Token
 }
 
-func (ipe *IntegerParseError) GetMsg() string { return Errs[INT_PARSE_ERR] }
+func (ipe *IntegerParseError) Code() string { return INT_PARSE_ERR }
+
+func (ipe *IntegerParseError) GetMsg() string { return Msg(INT_PARSE_ERR) }
 
 func (ipe *IntegerParseError) GetToken() token.Token { return ipe.Token }
 
@@ -74,10 +94,58 @@ func (ipe *IntegerParseError) String() string {
 	return fmt.Sprintf(ipe.GetMsg(), ipe.GetToken())
 }
 
-var Errs = map[string]string{
+type AssignTargetError struct {
+	Token token.Token
+}
+
+func (ate *AssignTargetError) Code() string { return INVALID_ASSIGN }
+
+func (ate *AssignTargetError) GetMsg() string { return Msg(INVALID_ASSIGN) }
+
+func (ate *AssignTargetError) GetToken() token.Token { return ate.Token }
+
+func (ate *AssignTargetError) String() string {
+	return fmt.Sprintf(ate.GetMsg(), ate.Token.Literal)
+}
+
+type OutsideLoopError struct {
+	Token token.Token
+}
+
+func (ole *OutsideLoopError) Code() string { return OUTSIDE_LOOP }
+
+func (ole *OutsideLoopError) GetMsg() string { return Msg(OUTSIDE_LOOP) }
+
+func (ole *OutsideLoopError) GetToken() token.Token { return ole.Token }
+
+func (ole *OutsideLoopError) String() string {
+	return fmt.Sprintf(ole.GetMsg(), ole.Token.Literal)
+}
+
+// IllegalTokenError is reported for characters the lexer does not
+// understand, and for strings and comments which are never closed
+type IllegalTokenError struct {
+	Token token.Token
+}
+
+func (ite *IllegalTokenError) Code() string {
+	switch {
+	case strings.HasPrefix(ite.Token.Literal, "\""), strings.HasPrefix(ite.Token.Literal, "`"):
+		return UNCLOSED_STRING
+	case ite.Token.Literal == "/*":
+		return UNCLOSED_COMMENT
+	default:
+		return ILLEGAL_TOKEN
+	}
+}
+
+func (ite *IllegalTokenError) GetMsg() string { return Msg(ite.Code()) }
+
+func (ite *IllegalTokenError) GetToken() token.Token { return ite.Token }
 
-	"NO_EKTI_BEFORE_FN":   "`কাজ`-এর আগে 'ekti' বা 'একটি' পাওয়া উচিত ছিল %s",
-	"EXPECTED_GOT":        "এখানে `%s` পাওয়া উচিত ছিল কিন্তু `%s` পাওয়া গেল",
-	"NO_PREFIX_SUFFIX_FN": "এটা %s নিয়ে কী করা উচিত আমি জানিনা",
-	"INT_PARSE_ERR":       "%s - এই এটা তো একটা সংখ্যা নয়",
+func (ite *IllegalTokenError) String() string {
+	if ite.Code() == ILLEGAL_TOKEN {
+		return fmt.Sprintf(ite.GetMsg(), ite.Token.Literal)
+	}
+	return fmt.Sprintf(ite.GetMsg(), ite.Token.Pos().String())
 }
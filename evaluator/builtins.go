@@ -2,13 +2,16 @@ package evaluator
 
 import (
 	"fmt"
+	"vabna/errs"
+	"vabna/locale"
+	"vabna/number"
 	"vabna/object"
 	"vabna/stdlib"
 )
 
 func lenFunc(args []object.Obj) object.Obj {
 	if len(args) != 1 {
-		return NewErr("wrong number of arguments. got %d but wanted 1", len(args))
+		return NewErr(errs.ARG_COUNT, 1, len(args))
 	}
 
 	switch arg := args[0].(type) {
@@ -17,18 +20,18 @@ func lenFunc(args []object.Obj) object.Obj {
 	case *object.Array:
 		return object.MakeIntNumber(int64(len(arg.Elms)))
 	default:
-		return NewErr("argument type %s to `len` is not supported", args[0].Type())
+		return NewErr(errs.ARG_TYPE, args[0].Type())
 	}
 }
 
 func firstFunc(args []object.Obj) object.Obj {
 
 	if len(args) != 1 {
-		return NewErr("wrong number of argument %d", len(args))
+		return NewErr(errs.ARG_COUNT, 1, len(args))
 	}
 
 	if args[0].Type() != object.ARRAY_OBJ {
-		return NewErr("first cannot be used with %s", args[0].Type())
+		return NewErr(errs.ARG_TYPE, args[0].Type())
 	}
 
 	array := args[0].(*object.Array)
@@ -41,11 +44,11 @@ func firstFunc(args []object.Obj) object.Obj {
 func lastFunc(args []object.Obj) object.Obj {
 
 	if len(args) != 1 {
-		return NewErr("wrong number of argument %d", len(args))
+		return NewErr(errs.ARG_COUNT, 1, len(args))
 	}
 
 	if args[0].Type() != object.ARRAY_OBJ {
-		return NewErr("last cannot be used with %s", args[0].Type())
+		return NewErr(errs.ARG_TYPE, args[0].Type())
 	}
 
 	array := args[0].(*object.Array)
@@ -59,11 +62,11 @@ func lastFunc(args []object.Obj) object.Obj {
 func restFunc(args []object.Obj) object.Obj {
 
 	if len(args) != 1 {
-		return NewErr("wrong number of argument %d", len(args))
+		return NewErr(errs.ARG_COUNT, 1, len(args))
 	}
 
 	if args[0].Type() != object.ARRAY_OBJ {
-		return NewErr("rest cannot be used with %s", args[0].Type())
+		return NewErr(errs.ARG_TYPE, args[0].Type())
 	}
 
 	array := args[0].(*object.Array)
@@ -79,11 +82,11 @@ func restFunc(args []object.Obj) object.Obj {
 func pushFunc(args []object.Obj) object.Obj {
 
 	if len(args) != 2 {
-		return NewErr("wrong number of argument %d", len(args))
+		return NewErr(errs.ARG_COUNT, 2, len(args))
 	}
 
 	if args[0].Type() != object.ARRAY_OBJ {
-		return NewErr("push cannot be used with %s", args[0].Type())
+		return NewErr(errs.ARG_TYPE, args[0].Type())
 	}
 
 	arr := args[0].(*object.Array)
@@ -95,6 +98,120 @@ func pushFunc(args []object.Obj) object.Obj {
 	return &object.Array{Elms: newElms}
 }
 
+func rangeFunc(args []object.Obj) object.Obj {
+	if len(args) < 1 || len(args) > 3 {
+		return NewErr(errs.ARG_RANGE, 1, 3, len(args))
+	}
+
+	nums := []int64{}
+	for _, arg := range args {
+		num, ok := arg.(*object.Number)
+		if !ok || !num.Value.IsInt {
+			return NewErr(errs.RANGE_NOT_INT, arg.Inspect())
+		}
+		n, ok := number.GetAsInt(num.Value)
+		if !ok {
+			return NewErr(errs.RANGE_TOO_LARGE, arg.Inspect())
+		}
+		nums = append(nums, n)
+	}
+
+	r := &object.Range{Start: 0, Step: 1}
+	switch len(nums) {
+	case 1:
+		r.Stop = nums[0]
+	case 2:
+		r.Start, r.Stop = nums[0], nums[1]
+	case 3:
+		r.Start, r.Stop, r.Step = nums[0], nums[1], nums[2]
+	}
+
+	if r.Step == 0 {
+		return NewErr(errs.RANGE_ZERO_STEP)
+	}
+
+	return r
+}
+
+// numeralNames are the names `numerals` accepts for each mode
+var numeralNames = map[string]number.Numerals{
+	"bn":      number.BengaliNumerals,
+	"বাংলা":   number.BengaliNumerals,
+	"bangla":  number.BengaliNumerals,
+	"en":      number.EnglishNumerals,
+	"ইংরেজি":  number.EnglishNumerals,
+	"english": number.EnglishNumerals,
+}
+
+// numeralsFunc sets the digits numbers are printed with and
+// returns the previous mode; without arguments it only returns it
+func numeralsFunc(args []object.Obj) object.Obj {
+	if len(args) > 1 {
+		return NewErr(errs.ARG_RANGE, 0, 1, len(args))
+	}
+
+	prev := "en"
+	if number.OutputNumerals == number.BengaliNumerals {
+		prev = "bn"
+	}
+
+	if len(args) == 1 {
+		name, ok := args[0].(*object.String)
+		if !ok {
+			return NewErr(errs.ARG_TYPE, args[0].Type())
+		}
+		mode, ok := numeralNames[name.Value]
+		if !ok {
+			return NewErr(errs.UNKNOWN_DIGITS, name.Value)
+		}
+		number.OutputNumerals = mode
+	}
+
+	return &object.String{Value: prev}
+}
+
+// formatFunc writes a number with lakh/crore grouping,
+// optionally with a fixed number of decimal places
+func formatFunc(args []object.Obj) object.Obj {
+	if len(args) < 1 || len(args) > 2 {
+		return NewErr(errs.ARG_RANGE, 1, 2, len(args))
+	}
+
+	num, ok := args[0].(*object.Number)
+	if !ok {
+		return NewErr(errs.ARG_TYPE, args[0].Type())
+	}
+
+	places := int64(-1)
+	if len(args) == 2 {
+		p, ok := args[1].(*object.Number)
+		if ok && p.Value.IsInt {
+			places, ok = number.GetAsInt(p.Value)
+		}
+		if !ok || places < 0 || places > 100 {
+			return NewErr(errs.DECIMAL_PLACES, args[1].Inspect())
+		}
+	}
+
+	return &object.String{Value: number.Format(num.Value, int(places))}
+}
+
+// ScriptArgs are the arguments given to the program on the command line
+var ScriptArgs []string
+
+// argsFunc returns the arguments of the program as strings
+func argsFunc(args []object.Obj) object.Obj {
+	if len(args) != 0 {
+		return NewErr(errs.ARG_COUNT, 0, len(args))
+	}
+
+	elms := make([]object.Obj, len(ScriptArgs))
+	for i, arg := range ScriptArgs {
+		elms[i] = &object.String{Value: arg}
+	}
+	return &object.Array{Elms: elms}
+}
+
 func showFunc(args []object.Obj) object.Obj {
 
 	for _, arg := range args {
@@ -103,6 +220,8 @@ func showFunc(args []object.Obj) object.Obj {
 	return NULL
 }
 
+// builtins are keyed by their names in the locale packs;
+// programs call them by any spelling of the packs in use
 var builtins = map[string]*object.Builtin{
 
 	"len": {
@@ -111,110 +230,74 @@ var builtins = map[string]*object.Builtin{
 		},
 	},
 
-	"আয়তন": {
-		Fn: func(args ...object.Obj) object.Obj {
-			return lenFunc(args)
-		},
-	},
-
-	"ayoton": {
-		Fn: func(args ...object.Obj) object.Obj {
-			return lenFunc(args)
-		},
-	},
-
-	"প্রথম": {
-		Fn: func(args ...object.Obj) object.Obj {
-			return firstFunc(args)
-		},
-	},
-
 	"first": {
 		Fn: func(args ...object.Obj) object.Obj {
 			return firstFunc(args)
 		},
 	},
 
-	"prothom": {
-		Fn: func(args ...object.Obj) object.Obj {
-			return firstFunc(args)
-		},
-	},
-
-	"শেষ": {
-		Fn: func(args ...object.Obj) object.Obj {
-			return lastFunc(args)
-		},
-	},
-
 	"last": {
 		Fn: func(args ...object.Obj) object.Obj {
 			return lastFunc(args)
 		},
 	},
 
-	"sesh": {
-		Fn: func(args ...object.Obj) object.Obj {
-			return lastFunc(args)
-		},
-	},
-
-	"বাদবাকি": {
-		Fn: func(args ...object.Obj) object.Obj {
-			return restFunc(args)
-		},
-	},
-
 	"rest": {
 		Fn: func(args ...object.Obj) object.Obj {
 			return restFunc(args)
 		},
 	},
 
-	"badbaki": {
-		Fn: func(args ...object.Obj) object.Obj {
-			return restFunc(args)
-		},
-	},
-
-	"যোগ": {
+	"push": {
 		Fn: func(args ...object.Obj) object.Obj {
 			return pushFunc(args)
 		},
 	},
 
-	"push": {
+	"show": {
 		Fn: func(args ...object.Obj) object.Obj {
-			return pushFunc(args)
+			return showFunc(args)
 		},
 	},
 
-	"jog": {
+	"range": {
 		Fn: func(args ...object.Obj) object.Obj {
-			return pushFunc(args)
+			return rangeFunc(args)
 		},
 	},
 
-	"দেখাও": {
+	"numerals": {
 		Fn: func(args ...object.Obj) object.Obj {
-			return showFunc(args)
+			return numeralsFunc(args)
 		},
 	},
 
-	"show": {
+	"format": {
 		Fn: func(args ...object.Obj) object.Obj {
-			return showFunc(args)
+			return formatFunc(args)
 		},
 	},
 
-	"dekhau": {
+	"epoch": {
 		Fn: func(args ...object.Obj) object.Obj {
-			return showFunc(args)
+			return stdlib.UnixTimeFunc(args)
 		},
 	},
-	"ইপচ": {
+
+	"args": {
 		Fn: func(args ...object.Obj) object.Obj {
-			return stdlib.UnixTimeFunc(args)
+			return argsFunc(args)
 		},
 	},
 }
+
+// BuiltinSpellings returns every spelling in use of the builtins, sorted
+func BuiltinSpellings() []string {
+	res := []string{}
+	for _, s := range locale.BuiltinSpellings() {
+		if name, _ := locale.BuiltinName(s); builtins[name] != nil {
+			res = append(res, s)
+		}
+	}
+	return res
+}
@@ -1,19 +1,46 @@
 package evaluator
 
 import (
-	"fmt"
+	"errors"
+	"strings"
 	"vabna/ast"
+	"vabna/errs"
+	"vabna/locale"
 	"vabna/number"
 	"vabna/object"
+	"vabna/token"
+	"vabna/trace"
 )
 
 var (
 	NULL  = &object.Null{}
 	TRUE  = &object.Boolean{Value: true}
 	FALSE = &object.Boolean{Value: false}
+
+	BREAK    = &object.Break{}
+	CONTINUE = &object.Continue{}
 )
 
+// callStack holds the user defined functions
+// currently being evaluated, innermost call last
+var callStack []object.Frame
+
+// Eval evaluates `node` in the environment `env`. Errors returned by
+// Eval always know which part of the source code caused them.
 func Eval(node ast.Node, env *object.Env) object.Obj {
+	res := eval(node, env)
+	trace.Step(node, res)
+
+	if err, ok := res.(*object.Error); ok && !err.Pos.IsValid() {
+		err.Pos = node.Pos()
+		err.End = node.End()
+		err.Trace = append([]object.Frame(nil), callStack...)
+	}
+
+	return res
+}
+
+func eval(node ast.Node, env *object.Env) object.Obj {
 	switch node := node.(type) {
 	case *ast.Program:
 		return evalProg(node, env)
@@ -31,6 +58,9 @@ func Eval(node ast.Node, env *object.Env) object.Obj {
 		}
 		return evalPrefixExpr(node.Op, r)
 	case *ast.InfixExpr:
+		if node.Op == token.AND || node.Op == token.OR {
+			return evalLogicalExpr(node, env)
+		}
 		l := Eval(node.Left, env)
 		if isErr(l) {
 			return l
@@ -40,10 +70,14 @@ func Eval(node ast.Node, env *object.Env) object.Obj {
 			return r
 		}
 		return evalInfixExpr(node.Op, l, r)
+	case *ast.AssignExpr:
+		return evalAssignExpr(node, env)
 	case *ast.IfExpr:
 		return evalIfExpr(node, env)
     case *ast.WhileExpr:
         return evalWhileExpr(node , env)
+	case *ast.ForEachExpr:
+		return evalForEachExpr(node, env)
 	case *ast.ReturnStmt:
 		val := Eval(node.ReturnVal, env)
 		if isErr(val) {
@@ -52,12 +86,24 @@ func Eval(node ast.Node, env *object.Env) object.Obj {
 		return &object.ReturnValue{Value: val}
 	case *ast.BlockStmt:
 		return evalBlockStmt(node, env)
+	case *ast.ImportStmt:
+		return evalImportStmt(node, env)
+	case *ast.MemberExpr:
+		return evalMemberExpr(node, env)
+	case *ast.BreakStmt:
+		return BREAK
+	case *ast.ContinueStmt:
+		return CONTINUE
 	case *ast.LetStmt:
 		val := Eval(node.Value, env)
 		if isErr(val) {
 			return val
 		}
 
+		if fn, ok := val.(*object.Function); ok && fn.Name == "" {
+			fn.Name = node.Name.Value
+		}
+
 		env.Set(node.Name.Value, val)
 	case *ast.Identifier:
 		return evalId(node, env)
@@ -76,10 +122,12 @@ func Eval(node ast.Node, env *object.Env) object.Obj {
 			return args[0]
 		}
 
-		return applyFunc(fnc, args)
+		return applyFunc(fnc, args, node.Pos())
 
 	case *ast.StringLit:
 		return &object.String{Value: node.Value}
+	case *ast.InterpStringLit:
+		return evalInterpStringLit(node, env)
 	case *ast.ArrLit:
 		elms := evalExprs(node.Elms, env)
 		if len(elms) == 1 && isErr(elms[0]) {
@@ -91,7 +139,7 @@ func Eval(node ast.Node, env *object.Env) object.Obj {
 	case *ast.IndexExpr:
 		left := Eval(node.Left, env)
 		if isErr(left) {
-			return nil
+			return left
 		}
 
 		index := Eval(node.Index, env)
@@ -108,9 +156,10 @@ func Eval(node ast.Node, env *object.Env) object.Obj {
 }
 
 func evalHashLit(node *ast.HashLit, env *object.Env) object.Obj {
-	pairs := make(map[object.HashKey]object.HashPair)
+	hash := &object.Hash{Pairs: make(map[object.HashKey]object.HashPair)}
 
-	for kNode, vNode := range node.Pairs {
+	for _, kNode := range node.Keys {
+		vNode := node.Pairs[kNode]
 
 		key := Eval(kNode, env)
 
@@ -120,7 +169,7 @@ func evalHashLit(node *ast.HashLit, env *object.Env) object.Obj {
 		hashkey, ok := key.(object.Hashable)
 
 		if !ok {
-			return NewErr("object cannot be used as hash key %s", key.Type())
+			return NewErr(errs.NOT_HASHABLE, key.Type())
 		}
 
 		val := Eval(vNode, env)
@@ -129,12 +178,10 @@ func evalHashLit(node *ast.HashLit, env *object.Env) object.Obj {
 			return val
 		}
 
-		hashed := hashkey.HashKey()
-
-		pairs[hashed] = object.HashPair{Key: key, Value: val}
+		hash.Set(hashkey.HashKey(), object.HashPair{Key: key, Value: val})
 	}
 
-	return &object.Hash{Pairs: pairs}
+	return hash
 }
 
 func evalIndexExpr(left, index object.Obj) object.Obj {
@@ -145,7 +192,7 @@ func evalIndexExpr(left, index object.Obj) object.Obj {
 	case left.Type() == object.HASH_OBJ:
 		return evalHashIndexExpr(left, index)
 	default:
-		return NewErr("Unsupported Index Operator %s ", left.Type())
+		return NewErr(errs.INDEX_UNSUPPORTED, left.Type())
 	}
 
 }
@@ -157,7 +204,7 @@ func evalHashIndexExpr(hash, index object.Obj) object.Obj {
 	key, ok := index.(object.Hashable)
 
 	if !ok {
-		return NewErr("This cannot be used as hash key %s", index.Type())
+		return NewErr(errs.NOT_HASHABLE, index.Type())
 	}
 
 	pair, ok := hashO.Pairs[key.HashKey()]
@@ -177,7 +224,7 @@ func evalArrIndexExpr(arr, index object.Obj) object.Obj {
 
     
     if !noerr{
-        return NewErr("Arr Index Failed")
+        return NewErr(errs.BAD_ARRAY_INDEX, index.Inspect())
     }
 	max := int64(len(arrObj.Elms) - 1)
 
@@ -188,22 +235,25 @@ func evalArrIndexExpr(arr, index object.Obj) object.Obj {
 	return arrObj.Elms[idx]
 }
 
-func applyFunc(fn object.Obj, args []object.Obj) object.Obj {
+func applyFunc(fn object.Obj, args []object.Obj, callPos token.Pos) object.Obj {
 
 	switch fn := fn.(type) {
 	case *object.Function:
 		if len(fn.Params) == len(args) {
+			callStack = append(callStack, object.Frame{Name: fn.Name, Pos: callPos})
+			defer func() { callStack = callStack[:len(callStack)-1] }()
+
 			eEnv := extendFuncEnv(fn, args)
 			evd := Eval(fn.Body, eEnv)
 			return unwrapRValue(evd)
 		} else {
 
-			return NewErr("Function call doesn't have required arguments provided; wanted = %d but got %d", len(fn.Params), len(args))
+			return NewErr(errs.WRONG_ARG_COUNT, len(fn.Params), len(args))
 		}
 	case *object.Builtin:
 		return fn.Fn(args...)
 	default:
-		return NewErr("%s is not a function", fn.Type())
+		return NewErr(errs.NOT_A_FUNCTION, fn.Type())
 
 	}
 }
@@ -250,16 +300,25 @@ func evalId(node *ast.Identifier, env *object.Env) object.Obj {
 		return val
 	}
 
-	if builtin, ok := builtins[node.Value]; ok {
-		return builtin
+	if name, ok := locale.BuiltinName(node.Value); ok {
+		if builtin, ok := builtins[name]; ok {
+			return builtin
+		}
 	}
 
-	return NewErr("id not found : " + node.Value)
+	return NewErr(errs.UNKNOWN_ID, node.Value)
 	//	return val
 }
 
-func NewErr(format string, a ...interface{}) *object.Error {
-	return &object.Error{Msg: fmt.Sprintf(format, a...)}
+// NewErr creates the error `code` of the error catalogs; object
+// types among the arguments are named in the language of the messages
+func NewErr(code string, a ...interface{}) *object.Error {
+	for i, arg := range a {
+		if typ, ok := arg.(object.ObjType); ok {
+			a[i] = errs.TypeName(string(typ))
+		}
+	}
+	return &object.Error{Code: code, Msg: errs.Format(code, a...)}
 }
 
 func isErr(obj object.Obj) bool {
@@ -281,7 +340,8 @@ func evalBlockStmt(block *ast.BlockStmt, env *object.Env) object.Obj {
 
 		if res != nil {
 			rtype := res.Type()
-			if rtype == object.RETURN_VAL_OBJ || rtype == object.ERR_OBJ {
+			if rtype == object.RETURN_VAL_OBJ || rtype == object.ERR_OBJ ||
+				rtype == object.BREAK_OBJ || rtype == object.CONTINUE_OBJ {
 				//fmt.Println("RET => " ,  res)
 				return res
 			}
@@ -308,6 +368,114 @@ func evalProg(prog *ast.Program, env *object.Env) object.Obj {
 	return res
 }
 
+func evalAssignExpr(node *ast.AssignExpr, env *object.Env) object.Obj {
+	switch target := node.Target.(type) {
+	case *ast.Identifier:
+		return evalIdentAssign(node, target, env)
+	case *ast.IndexExpr:
+		return evalIndexAssign(node, target, env)
+	default:
+		return NewErr(errs.BAD_ASSIGN_TARGET, node.Target.String())
+	}
+}
+
+func evalIdentAssign(node *ast.AssignExpr, target *ast.Identifier, env *object.Env) object.Obj {
+	var cur object.Obj
+	if node.Op != "" {
+		cur = evalId(target, env)
+		if isErr(cur) {
+			return cur
+		}
+	}
+
+	val := assignedValue(node, cur, env)
+	if isErr(val) {
+		return val
+	}
+
+	if _, ok := env.Assign(target.Value, val); !ok {
+		return NewErr(errs.UNDECLARED, target.Value, locale.Spelling(token.LET))
+	}
+
+	return val
+}
+
+func evalIndexAssign(node *ast.AssignExpr, target *ast.IndexExpr, env *object.Env) object.Obj {
+	left := Eval(target.Left, env)
+	if isErr(left) {
+		return left
+	}
+
+	index := Eval(target.Index, env)
+	if isErr(index) {
+		return index
+	}
+
+	switch coll := left.(type) {
+	case *object.Array:
+		idx, err := arrIndex(coll, index)
+		if err != nil {
+			return err
+		}
+
+		val := assignedValue(node, coll.Elms[idx], env)
+		if isErr(val) {
+			return val
+		}
+
+		coll.Elms[idx] = val
+		return val
+	case *object.Hash:
+		key, ok := index.(object.Hashable)
+		if !ok {
+			return NewErr(errs.NOT_HASHABLE, index.Type())
+		}
+		hashed := key.HashKey()
+
+		pair, exists := coll.Pairs[hashed]
+		if node.Op != "" && !exists {
+			return NewErr(errs.KEY_NOT_FOUND, index.Inspect())
+		}
+
+		val := assignedValue(node, pair.Value, env)
+		if isErr(val) {
+			return val
+		}
+
+		coll.Set(hashed, object.HashPair{Key: index, Value: val})
+		return val
+	default:
+		return NewErr(errs.INDEX_ASSIGN, left.Type())
+	}
+}
+
+// assignedValue evaluates the right hand side of an assignment.
+// For compound assignments like `+=`, it is combined with `cur`,
+// the current value of the target.
+func assignedValue(node *ast.AssignExpr, cur object.Obj, env *object.Env) object.Obj {
+	val := Eval(node.Value, env)
+	if isErr(val) || node.Op == "" {
+		return val
+	}
+
+	return evalInfixExpr(node.Op, cur, val)
+}
+
+// arrIndex checks that `index` is an integer within the bounds of `arr`
+func arrIndex(arr *object.Array, index object.Obj) (int64, *object.Error) {
+	num, ok := index.(*object.Number)
+	if !ok || !num.Value.IsInt {
+		return 0, NewErr(errs.BAD_ARRAY_INDEX, index.Inspect())
+	}
+
+	idx, ok := number.GetAsInt(num.Value)
+	if !ok || idx < 0 || idx >= int64(len(arr.Elms)) {
+		return 0, NewErr(errs.INDEX_OUT_OF_RANGE, index.Inspect(), len(arr.Elms))
+	}
+
+	return idx, nil
+}
+
 func evalIfExpr(iex *ast.IfExpr, env *object.Env) object.Obj {
 	cond := Eval(iex.Cond, env)
 
@@ -334,12 +502,112 @@ func evalWhileExpr(wx *ast.WhileExpr , env *object.Env) object.Obj{
 
     for isTruthy(cond){
         result = Eval(wx.StmtBlock , env)
+
+        if isLoopExit(result) {
+            return result
+        }
+        if result == BREAK {
+            return NULL
+        }
+        if result == CONTINUE {
+            result = NULL
+        }
+
         cond = Eval(wx.Cond , env)
+        if isErr(cond){
+            return cond
+        }
     }
 
     return result
 }
 
+func evalForEachExpr(fx *ast.ForEachExpr, env *object.Env) object.Obj {
+	iter := Eval(fx.Iter, env)
+	if isErr(iter) {
+		return iter
+	}
+
+	var result object.Obj
+
+	// step runs the loop body once in a fresh scope. With a single
+	// loop variable only `val` is bound, otherwise `key` and `val`.
+	step := func(key, val object.Obj) bool {
+		loopEnv := object.NewEnclosedEnv(env)
+		if len(fx.Vars) == 1 {
+			loopEnv.Set(fx.Vars[0].Value, val)
+		} else {
+			loopEnv.Set(fx.Vars[0].Value, key)
+			loopEnv.Set(fx.Vars[1].Value, val)
+		}
+
+		result = Eval(fx.StmtBlock, loopEnv)
+		switch {
+		case isLoopExit(result):
+			return false
+		case result == BREAK:
+			result = NULL
+			return false
+		case result == CONTINUE:
+			result = NULL
+		}
+		return true
+	}
+
+	switch iter := iter.(type) {
+	case *object.Array:
+		for i := 0; i < len(iter.Elms); i++ {
+			if !step(object.MakeIntNumber(int64(i)), iter.Elms[i]) {
+				break
+			}
+		}
+	case *object.Hash:
+		keys := append([]object.HashKey(nil), iter.Order...)
+		for _, k := range keys {
+			pair, ok := iter.Pairs[k]
+			if !ok {
+				continue
+			}
+			// a single loop variable gets the keys of a hash
+			val := pair.Value
+			if len(fx.Vars) == 1 {
+				val = pair.Key
+			}
+			if !step(pair.Key, val) {
+				break
+			}
+		}
+	case *object.String:
+		for i, r := range []rune(iter.Value) {
+			if !step(object.MakeIntNumber(int64(i)), &object.String{Value: string(r)}) {
+				break
+			}
+		}
+	case *object.Range:
+		for i := uint64(0); i < iter.Len(); i++ {
+			n := object.MakeIntNumber(iter.At(i))
+			idx := &object.Number{Value: number.MakeUint(i), IsInt: true}
+			if !step(idx, n) {
+				break
+			}
+		}
+	default:
+		return NewErr(errs.NOT_ITERABLE, iter.Type())
+	}
+
+	return result
+}
+
+// isLoopExit reports whether `res` must stop the loop
+// which evaluated it and be passed on to the enclosing code
+func isLoopExit(res object.Obj) bool {
+	if res == nil {
+		return false
+	}
+	rtype := res.Type()
+	return rtype == object.RETURN_VAL_OBJ || rtype == object.ERR_OBJ
+}
+
 func isTruthy(obj object.Obj) bool {
 	switch obj {
 	case NULL:
@@ -368,15 +636,53 @@ func evalInfixExpr(op string, l, r object.Obj) object.Obj {
 	case op == "!=":
 		return getBoolObj(l != r)
 	case l.Type() != r.Type():
-		return NewErr("Type mismatch:  %s %s %s ", l.Type(), op, r.Type())
+		return NewErr(errs.TYPE_MISMATCH, l.Type(), r.Type(), op)
 	default:
-		return NewErr("unknown Operator : %s %s %s", l.Type(), op, r.Type())
+		return NewErr(errs.UNKNOWN_OP, l.Type(), r.Type(), op)
+	}
+}
+
+// evalLogicalExpr evaluates `&&` and `||` lazily; the right side is
+// only evaluated when needed, and the operand which decided the
+// result is returned as it is
+func evalLogicalExpr(node *ast.InfixExpr, env *object.Env) object.Obj {
+	l := Eval(node.Left, env)
+	if isErr(l) {
+		return l
+	}
+
+	if node.Op == token.AND && !isTruthy(l) {
+		return l
+	}
+	if node.Op == token.OR && isTruthy(l) {
+		return l
+	}
+
+	return Eval(node.Right, env)
+}
+
+// evalInterpStringLit joins the text parts with the
+// `Inspect` output of the values of the `{}` parts
+func evalInterpStringLit(node *ast.InterpStringLit, env *object.Env) object.Obj {
+	var out strings.Builder
+
+	for _, part := range node.Parts {
+		val := Eval(part, env)
+		if isErr(val) {
+			return val
+		}
+		if val == nil {
+			val = NULL
+		}
+		out.WriteString(val.Inspect())
 	}
+
+	return &object.String{Value: out.String()}
 }
 
 func evalStringInfixExpr(op string, l, r object.Obj) object.Obj {
 	if op != "+" {
-		return NewErr("Unknown Operator %s %s %s", l.Type(), op, r.Type())
+		return NewErr(errs.UNKNOWN_OP, l.Type(), r.Type(), op)
 	}
 
 	lval := l.(*object.String).Value
@@ -392,19 +698,41 @@ func evalNumInfixExpr(op string , l,r object.Obj) object.Obj{
     
     //fmt.Println(lval.GetType() , rval.GetType())
      
-    val,cval,noerr := number.NumberOperation(op , lval , rval)
-    if val.Value != nil && noerr{
+    val,cval,err := number.NumberOperation(op , lval , rval)
+    if err != nil{
+        return numberErr(err)
+    }else if val.Value != nil{
         return &object.Number{ Value: val , IsInt: val.IsInt }
-    }else if val.Value == nil && noerr{
-       return getBoolObj(cval) 
     }else{
-        return NewErr("Unknown Operator for Numbers %s" , op)
+       return getBoolObj(cval) 
     }
      
 }
 
 
 
+// numberErr converts errors of the number package to
+// runtime errors with messages in Bengali
+func numberErr(err error) *object.Error {
+	var nerr *number.Error
+	if !errors.As(err, &nerr) {
+		return NewErr(errs.NUM_ERROR, err)
+	}
+
+	switch nerr.Kind {
+	case number.DivByZero:
+		return NewErr(errs.DIV_BY_ZERO)
+	case number.NotANumber:
+		return NewErr(errs.NOT_A_NUMBER, nerr.Op)
+	case number.Infinite:
+		return NewErr(errs.INFINITE, nerr.Op)
+	case number.Overflow:
+		return NewErr(errs.NUM_OVERFLOW, nerr.Op, number.MaxIntBits)
+	default:
+		return NewErr(errs.NUM_UNKNOWN_OP, nerr.Op)
+	}
+}
+
 func evalPrefixExpr(op string, right object.Obj) object.Obj {
 	switch op {
 	case "!":
@@ -412,14 +740,14 @@ func evalPrefixExpr(op string, right object.Obj) object.Obj {
 	case "-":
 		return evalMinusPrefOp(right)
 	default:
-		return NewErr("Unknown Operator : %s%s", op, right.Type())
+		return NewErr(errs.UNKNOWN_PREFIX_OP, right.Type(), op)
 
 	}
 }
 
 func evalMinusPrefOp(right object.Obj) object.Obj {
 	if right.Type() != object.NUM_OBJ {
-		return NewErr("unknown Operator : -%s", right.Type())
+		return NewErr(errs.UNKNOWN_PREFIX_OP, right.Type(), "-")
 	}
     num := right.(*object.Number)
     return &object.Number{ Value: number.MakeNeg(num.Value) , IsInt: num.IsInt}
@@ -0,0 +1,493 @@
+package evaluator
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"vabna/errs"
+	"vabna/lexer"
+	"vabna/locale"
+	"vabna/number"
+	"vabna/object"
+	"vabna/parser"
+)
+
+func testEval(t *testing.T, input string) object.Obj {
+	t.Helper()
+
+	l := lexer.NewLexer(input)
+	p := parser.NewParser(&l)
+	prog := p.ParseProg()
+
+	if len(p.GetErrors()) != 0 {
+		for _, e := range p.GetErrors() {
+			t.Errorf("parser error -> %s", e.String())
+		}
+		t.FailNow()
+	}
+
+	return Eval(prog, object.NewEnv())
+}
+
+func testInspect(t *testing.T, tests []struct {
+	input    string
+	expected string
+}) {
+	t.Helper()
+
+	for i, tt := range tests {
+		res := testEval(t, tt.input)
+		if res == nil {
+			t.Fatalf("tests[%d] -> Got nil for %q", i, tt.input)
+		}
+		if res.Inspect() != tt.expected {
+			t.Fatalf("tests[%d] -> Expected=%q, Got=%q", i, tt.expected, res.Inspect())
+		}
+	}
+}
+
+func testError(t *testing.T, input string, expectedCode string) *object.Error {
+	t.Helper()
+
+	res := testEval(t, input)
+	err, ok := res.(*object.Error)
+	if !ok {
+		t.Fatalf("Expected an error for %q, Got=%v", input, res)
+	}
+	if err.Code != expectedCode {
+		t.Fatalf("Error code wrong -> Expected=%s, Got=%s (%s)", expectedCode, err.Code, err.Msg)
+	}
+	return err
+}
+
+func TestErrorPosition(t *testing.T) {
+	err := testError(t, "ধরি ক = 1;\nধরি খ = ক + \"হ্যালো\";", errs.TYPE_MISMATCH)
+
+	if err.Pos.Line != 2 || err.Pos.Column != 9 {
+		t.Fatalf("Error position wrong -> Expected=2:9, Got=%d:%d", err.Pos.Line, err.Pos.Column)
+	}
+
+	expected := "  2 | ধরি খ = ক + \"হ্যালো\";\n    |         ^^^^^^^^^^^"
+	if err.Excerpt() != expected {
+		t.Fatalf("Excerpt wrong -> Expected=\n%s\nGot=\n%s", expected, err.Excerpt())
+	}
+}
+
+func TestErrorEnd(t *testing.T) {
+	tests := []struct {
+		input string
+		line  int
+		col   int
+	}{
+		{`1 + "ক"`, 1, 8},
+		// the end is where the string ends in the source, not after its value
+		{`1 + "\u{9AD}x"`, 1, 15},
+		{`1 + "\n"`, 1, 9},
+		{`1 + "{"\{"}"`, 1, 13},
+		{"1 + `ক\nখগ`", 2, 4},
+		{"1 + আয়তন", 1, 10},
+	}
+
+	for i, tt := range tests {
+		err := testError(t, tt.input, errs.TYPE_MISMATCH)
+		if err.End.Line != tt.line || err.End.Column != tt.col {
+			t.Fatalf("tests[%d] -> End wrong, Expected=%d:%d, Got=%d:%d", i, tt.line, tt.col, err.End.Line, err.End.Column)
+		}
+	}
+}
+
+func TestStackTrace(t *testing.T) {
+	input := `
+ধরি ভাগ = একটি কাজ(ক){ ক + "x" };
+ধরি গড় = একটি কাজ(ক){ ভাগ(ক) };
+গড়(1);
+`
+	err := testError(t, input, errs.TYPE_MISMATCH)
+
+	if len(err.Trace) != 2 {
+		t.Fatalf("Expected 2 frames, Got=%d", len(err.Trace))
+	}
+	if err.Trace[0].Name != "গড়" || err.Trace[0].Pos.Line != 4 {
+		t.Fatalf("frame[0] wrong -> Got=%s at %s", err.Trace[0].Name, err.Trace[0].Pos)
+	}
+	if err.Trace[1].Name != "ভাগ" || err.Trace[1].Pos.Line != 3 {
+		t.Fatalf("frame[1] wrong -> Got=%s at %s", err.Trace[1].Name, err.Trace[1].Pos)
+	}
+}
+
+func TestAssignment(t *testing.T) {
+	testInspect(t, []struct {
+		input    string
+		expected string
+	}{
+		{"ধরি ক = 1; ক = 2; ক", "2"},
+		{"ধরি ক = 1; ক += 4; ক", "5"},
+		{"ধরি ক = 10; ক -= 4; ক *= 3; ক /= 2; ক", "9"},
+		{"ধরি ক = 1; ধরি খ = 1; ক = খ = 7; ক + খ", "14"},
+		{"ধরি ক = \"হ্যালো\"; ক += \" দুনিয়া\"; ক", "হ্যালো দুনিয়া"},
+		{`
+ধরি গণনা = 0;
+ধরি বাড়াও = একটি কাজ(){
+    ধরি i = 0;
+    jotokhon (i < 5){
+        গণনা += 1;
+        i = i + 1;
+    }
+};
+বাড়াও();
+গণনা`, "5"},
+	})
+
+	testError(t, "অজানা = 1;", errs.UNDECLARED)
+	testError(t, "ধরি ক = 1; ক += \"x\";", errs.TYPE_MISMATCH)
+}
+
+func TestIndexAssignment(t *testing.T) {
+	testInspect(t, []struct {
+		input    string
+		expected string
+	}{
+		{"ধরি ক = [1, 2, 3]; ক[0] = 10; ক", "[10, 2, 3]"},
+		{"ধরি ক = [1, 2, 3]; ক[len(ক) - 1] += 5; ক", "[1, 2, 8]"},
+		{"ধরি ক = [[1], [2]]; ক[1][0] = 7; ক", "[[1], [7]]"},
+		{`ধরি ব = {"নাম": "পলাশ"}; ব["নাম"] = "ভাবনা"; ব["নাম"]`, "ভাবনা"},
+		{`ধরি ব = {}; ব["বয়স"] = 20; ব["বয়স"] += 1; ব["বয়স"]`, "21"},
+		{"ধরি ক = [1]; ধরি খ = ক; খ[0] = 2; ক", "[2]"},
+	})
+
+	testError(t, "ধরি ক = [1, 2]; ক[2] = 0;", errs.INDEX_OUT_OF_RANGE)
+	testError(t, "ধরি ক = [1, 2]; ক[-1] = 0;", errs.INDEX_OUT_OF_RANGE)
+	testError(t, `ধরি ব = {}; ব["ক"] += 1;`, errs.KEY_NOT_FOUND)
+	testError(t, `ধরি স = "abc"; স[0] = "x";`, errs.INDEX_ASSIGN)
+}
+
+func TestForEach(t *testing.T) {
+	testInspect(t, []struct {
+		input    string
+		expected string
+	}{
+		{"ধরি যোগফল = 0; প্রতিটি (ক মধ্যে [1, 2, 3]) { যোগফল += ক; } যোগফল", "6"},
+		{"ধরি ফল = []; প্রতিটি (i, ক মধ্যে [5, 6]) { ফল = push(ফল, i); } ফল", "[0, 1]"},
+		{`ধরি ফল = ""; protiti (k moddhe {"ক": 1, "খ": 2}) { ফল += k; } ফল`, "কখ"},
+		{`ধরি ফল = 0; for (k, v in {"ক": 1, "খ": 2}) { ফল += v; } ফল`, "3"},
+		{`ধরি ফল = []; প্রতিটি (অ মধ্যে "কখগ") { ফল = push(ফল, অ); } ফল`, "[ক, খ, গ]"},
+		{"ধরি ফল = []; প্রতিটি (n মধ্যে পরিসর(3)) { ফল = push(ফল, n); } ফল", "[0, 1, 2]"},
+		{"ধরি ফল = []; প্রতিটি (n মধ্যে range(10, 0, -4)) { ফল = push(ফল, n); } ফল", "[10, 6, 2]"},
+		{"ধরি ফল = []; প্রতিটি (n মধ্যে range(-9223372036854775808, 9223372036854775807, 9223372036854775807)) { ফল = push(ফল, n); } ফল", "[-9223372036854775808, -1, 9223372036854775806]"},
+		{"ধরি ফল = []; প্রতিটি (n মধ্যে range(9223372036854775807, -9223372036854775808, -9223372036854775808)) { ফল = push(ফল, n); } ফল", "[9223372036854775807, -1]"},
+		{"ধরি ক = 1; প্রতিটি (ক মধ্যে [5]) { ক } ক", "1"},
+		{"ধরি খ = একটি কাজ(){ প্রতিটি (ক মধ্যে [1, 2, 3]) { যদি (ক == 2) তাহলে { ফেরাও ক; } } }; খ()", "2"},
+	})
+
+	testError(t, "প্রতিটি (ক মধ্যে 5) { ক }", errs.NOT_ITERABLE)
+	testError(t, "প্রতিটি (ক মধ্যে [1]) { ধরি খ = ক; } খ", errs.UNKNOWN_ID)
+}
+
+func TestRangeLen(t *testing.T) {
+	tests := []struct {
+		r        object.Range
+		expected uint64
+	}{
+		{object.Range{Start: 0, Stop: 10, Step: 3}, 4},
+		{object.Range{Start: 10, Stop: 0, Step: -3}, 4},
+		{object.Range{Start: 0, Stop: 0, Step: 1}, 0},
+		{object.Range{Start: 0, Stop: 10, Step: -1}, 0},
+		{object.Range{Start: math.MinInt64, Stop: math.MaxInt64, Step: 1}, math.MaxUint64},
+		{object.Range{Start: math.MaxInt64, Stop: math.MinInt64, Step: -1}, math.MaxUint64},
+		{object.Range{Start: math.MinInt64, Stop: math.MaxInt64, Step: math.MaxInt64}, 3},
+		{object.Range{Start: math.MaxInt64, Stop: math.MinInt64, Step: math.MinInt64}, 2},
+		{object.Range{Start: math.MaxInt64 - 1, Stop: math.MaxInt64, Step: math.MaxInt64}, 1},
+	}
+
+	for i, tt := range tests {
+		if got := tt.r.Len(); got != tt.expected {
+			t.Fatalf("tests[%d] -> Expected=%d, Got=%d", i, tt.expected, got)
+		}
+		if tt.expected > 0 {
+			last := tt.r.At(tt.expected - 1)
+			if tt.r.Step > 0 && (last < tt.r.Start || last >= tt.r.Stop) || tt.r.Step < 0 && (last > tt.r.Start || last <= tt.r.Stop) {
+				t.Fatalf("tests[%d] -> Last integer %d is out of the range", i, last)
+			}
+		}
+	}
+}
+
+func TestBreakContinue(t *testing.T) {
+	testInspect(t, []struct {
+		input    string
+		expected string
+	}{
+		{"ধরি i = 0; jotokhon (সত্য) { i += 1; যদি (i == 5) তাহলে { থামো; } } i", "5"},
+		{"ধরি i = 0; ধরি ফল = 0; jotokhon (i < 6) { i += 1; যদি (i < 4) তাহলে { চালাও; } ফল += i; } ফল", "15"},
+		{"ধরি ফল = []; প্রতিটি (ক মধ্যে [1, 2, 3, 4]) { যদি (ক == 3) তাহলে { break; } ফল = push(ফল, ক); } ফল", "[1, 2]"},
+		{"ধরি ফল = []; প্রতিটি (ক মধ্যে [1, 2, 3, 4]) { যদি (ক == 3) তাহলে { continue; } ফল = push(ফল, ক); } ফল", "[1, 2, 4]"},
+		{`ধরি ফল = 0;
+প্রতিটি (ক মধ্যে পরিসর(3)) {
+    প্রতিটি (খ মধ্যে পরিসর(3)) {
+        যদি (খ > ক) তাহলে { থামো; }
+        ফল += 1;
+    }
+}
+ফল`, "6"},
+	})
+}
+
+func TestLogicalOperators(t *testing.T) {
+	testInspect(t, []struct {
+		input    string
+		expected string
+	}{
+		{"সত্য && মিথ্যা", "false"},
+		{"সত্য এবং সত্য", "true"},
+		{"মিথ্যা || সত্য", "true"},
+		{"মিথ্যা অথবা মিথ্যা", "false"},
+		{"ধরি বয়স = 20; বয়স >= 18 && বয়স < 60", "true"},
+		{"ধরি বয়স = 70; বয়স < 18 or বয়স >= 60 and সত্য", "true"},
+		{`মিথ্যা || "হ্যাঁ"`, "হ্যাঁ"},
+		{`"প্রথম" && "দ্বিতীয়"`, "দ্বিতীয়"},
+		{"ধরি ক = 1; মিথ্যা && (ক = 2); ক", "1"},
+		{"ধরি ক = 1; সত্য || অজানা(); ক", "1"},
+	})
+
+	testError(t, "সত্য && অজানা", errs.UNKNOWN_ID)
+}
+
+func TestStringInterpolation(t *testing.T) {
+	testInspect(t, []struct {
+		input    string
+		expected string
+	}{
+		{`ধরি বয়স = 20; "বয়স {বয়স + 1} বছর"`, "বয়স 21 বছর"},
+		{`"{[1, 2]} {সত্য} {1.5 * 2}"`, "[1, 2] true 3"},
+		{`ধরি নাম = "রহিম"; "নাম: {নাম}, {"{নাম}!"}"`, "নাম: রহিম, রহিম!"},
+		{`"\{নয়\}"`, "{নয়}"},
+		{"`{নয়}`", "{নয়}"},
+	})
+
+	err := testError(t, `"ক\n{অজানা}"`, errs.UNKNOWN_ID)
+	if err.Pos.Line != 1 || err.Pos.Column != 6 {
+		t.Fatalf("Error position wrong -> Got=%s", err.Pos)
+	}
+}
+
+func TestNumberOperators(t *testing.T) {
+	testInspect(t, []struct {
+		input    string
+		expected string
+	}{
+		{"7 % 2", "1"},
+		{"৮ % ২ == ০", "true"},
+		{"-7 % 3", "2"},
+		{"7 % -3", "-2"},
+		{"7 ~/ 2", "3"},
+		{"-7 ~/ 2", "-4"},
+		{"7.5 ~/ 2", "3"},
+		{"7.5 % 2", "1.5"},
+		{"-7.5 % 2", "0.5"},
+		{"2 ** 10", "1024"},
+		{"2 ** 3 ** 2", "512"},
+		{"-2 ** 2", "-4"},
+		{"2 ** -1", "0.5"},
+		{"1.5 ** 2", "2.25"},
+		{"4 ** 0.5", "2"},
+		{"27 ** (1.0 / 3)", "3"},
+		{"10.0 ** 400.5", "3.16227766e+400"},
+		{"2 * 3 ** 2 % 5", "3"},
+		{"2 ** 100", "1267650600228229401496703205376"},
+	})
+
+	testError(t, "1 / 0", errs.DIV_BY_ZERO)
+	testError(t, "1.5 / 0", errs.DIV_BY_ZERO)
+	testError(t, "1 % 0", errs.DIV_BY_ZERO)
+	testError(t, "1 ~/ 0.0", errs.DIV_BY_ZERO)
+	testError(t, "0 ** -1", errs.DIV_BY_ZERO)
+}
+
+func TestNumberErrors(t *testing.T) {
+	testError(t, "১ / ০", errs.DIV_BY_ZERO)
+	testError(t, "(-8) ** 0.5", errs.NOT_A_NUMBER)
+	testError(t, "10.0 ** 10000000000.5", errs.INFINITE)
+	testError(t, "3 ** 10000000", errs.NUM_OVERFLOW)
+	testError(t, "ধরি ক = 2 ** 1000000; ক * ক", errs.NUM_OVERFLOW)
+
+	err := testError(t, "ধরি ক = 10;\nক / (ক - 10)", errs.DIV_BY_ZERO)
+	if err.Pos.Line != 2 || err.Pos.Column != 1 {
+		t.Fatalf("Error position wrong -> Got=%s", err.Pos)
+	}
+}
+
+func evalFile(t *testing.T, path string) object.Obj {
+	t.Helper()
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	l := lexer.NewFileLexer(path, string(src))
+	p := parser.NewParser(&l)
+	prog := p.ParseProg()
+	if len(p.GetErrors()) != 0 {
+		t.Fatalf("parser error -> %s", p.GetErrors()[0].String())
+	}
+
+	return Eval(prog, object.NewEnv())
+}
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	for name, src := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestUnicodeNames(t *testing.T) {
+	testInspect(t, []struct {
+		input    string
+		expected string
+	}{
+		{"ধরি ব\u09dfস = 20; ব\u09af\u09bcস", "20"},
+		{"ধরি দ\u09c7\u09beকান = 1; দ\u09cbকান + 1", "2"},
+		{"ধরি x1 = 2; ধরি x2 = 3; x1 * x2", "6"},
+		{"আ\u09dfতন([1, 2])", "2"},
+	})
+
+	for _, name := range locale.Builtins() {
+		if _, ok := builtins[name]; !ok {
+			t.Fatalf("builtin %q of the locale packs is not defined", name)
+		}
+	}
+}
+
+func TestEnglishNames(t *testing.T) {
+	// only the English words of the roman pack are keywords by default
+	testInspect(t, []struct {
+		input    string
+		expected string
+	}{
+		{"ধরি new = 1; ধরি is = 2; new + is", "3"},
+		{"ধরি if = 1; ধরি then = 2; ধরি else = 3; ধরি return = 4; if + then + else + return", "10"},
+		{"ধরি true = 1; ধরি false = 2; true - false", "-1"},
+		{"let f = ekti fn(x) { x * 2 }; f(21)", "42"},
+	})
+}
+
+func TestImport(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"main.vab": `আনো "lib/গণিত.vab"; আনো "lib/গণিত"; গণিত.যোগফল(গণিত.দুই, 3)`,
+		"lib/গণিত.vab": `
+আনো "সহায়ক.vab";
+ধরি দুই = সহায়ক.এক + 1;
+ধরি যোগফল = একটি কাজ(ক, খ) { ক + খ };
+`,
+		"lib/সহায়ক.vab": `ধরি এক = 1;`,
+		"a.vab":         `ano "b";`,
+		"b.vab":         `ano "a";`,
+		"bad.vab":       `import "lib/গণিত.vab"; গণিত.বিয়োগ`,
+	})
+
+	res := evalFile(t, filepath.Join(dir, "main.vab"))
+	if res == nil || res.Inspect() != "5" {
+		t.Fatalf("Expected=5, Got=%v", res)
+	}
+
+	res = evalFile(t, filepath.Join(dir, "a.vab"))
+	if err, ok := res.(*object.Error); !ok || err.Code != errs.CIRCULAR_IMPORT || !strings.Contains(err.Msg, "a.vab -> b.vab -> a.vab") {
+		t.Fatalf("Expected circular import error, Got=%v", res)
+	}
+
+	res = evalFile(t, filepath.Join(dir, "bad.vab"))
+	if err, ok := res.(*object.Error); !ok || err.Code != errs.NO_MEMBER || !strings.Contains(err.Msg, "বিয়োগ") {
+		t.Fatalf("Expected missing member error, Got=%v", res)
+	}
+
+	err := testError(t, `আনো "নেই.vab";`, errs.IMPORT_FAILED)
+	if expected := "`নেই.vab` আনা যায় না: ফাইলটি নেই"; err.Msg != expected {
+		t.Fatalf("Expected=%q, Got=%q", expected, err.Msg)
+	}
+}
+
+func TestImportLocale(t *testing.T) {
+	defer locale.Select(strings.Join(locale.DefaultPacks, ","), "")
+	if err := locale.Select("bn", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	dir := writeFiles(t, map[string]string{
+		"main.vab": `আনো "সংখ্যা.vab"; সংখ্যা.গোনো([1, 2]) + সংখ্যা.x`,
+		"সংখ্যা.vab": "# locale: en\nlet x = 5;\nlet গোনো = fn(a) { len(a) };\n",
+		"len.vab":  `আনো "সংখ্যা.vab"; সংখ্যা.len`,
+	})
+
+	res := evalFile(t, filepath.Join(dir, "main.vab"))
+	if res == nil || res.Inspect() != "7" {
+		t.Fatalf("Expected=7, Got=%v", res)
+	}
+	if packs := locale.Active(); len(packs) != 1 || packs[0].Name != "bn" {
+		t.Fatalf("The packs of the importer should be back in use, Got=%v", packs)
+	}
+
+	res = evalFile(t, filepath.Join(dir, "len.vab"))
+	if err, ok := res.(*object.Error); !ok || err.Code != errs.NO_MEMBER {
+		t.Fatalf("Builtins are not members of a module, Got=%v", res)
+	}
+}
+
+func TestNumerals(t *testing.T) {
+	defer func() { number.OutputNumerals = number.EnglishNumerals }()
+
+	testInspect(t, []struct {
+		input    string
+		expected string
+	}{
+		{"format(12345678)", "1,23,45,678"},
+		{"format(-1234567.5)", "-12,34,567.5"},
+		{"format(123)", "123"},
+		{"format(1234, 2)", "1,234.00"},
+		{"numerals()", "en"},
+		{`numerals("বাংলা"); ১০০ * ৩`, "৩০০"},
+		{"২০.৫ + 1", "২১.৫"},
+		{`"মোট {১২৩ + 1}টি"`, "মোট ১২৪টি"},
+		{"[1, 2]", "[১, ২]"},
+		{"বিন্যাস(১২৩৪৫৬৭৮৯)", "১২,৩৪,৫৬,৭৮৯"},
+		{`ongko("en"); ongko()`, "en"},
+		{"১০০", "100"},
+	})
+
+	testError(t, `numerals("fr")`, errs.UNKNOWN_DIGITS)
+	testError(t, `format("১")`, errs.ARG_TYPE)
+	testError(t, "format(1, -1)", errs.DECIMAL_PLACES)
+}
+
+func TestScriptArgs(t *testing.T) {
+	defer func() { ScriptArgs = nil }()
+
+	testInspect(t, []struct {
+		input    string
+		expected string
+	}{
+		{"args()", "[]"},
+	})
+
+	ScriptArgs = []string{"ক", "--x"}
+	testInspect(t, []struct {
+		input    string
+		expected string
+	}{
+		{"যুক্তি()", "[ক, --x]"},
+		{"len(jukti())", "2"},
+	})
+
+	testError(t, "args(1)", errs.ARG_COUNT)
+}
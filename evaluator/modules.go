@@ -0,0 +1,175 @@
+package evaluator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"vabna/ast"
+	"vabna/errs"
+	"vabna/lexer"
+	"vabna/locale"
+	"vabna/object"
+	"vabna/parser"
+	"vabna/token"
+)
+
+// ModuleExt is the extension added to imported paths which have none
+const ModuleExt = ".vab"
+
+var (
+	// modules caches every imported module by its absolute path,
+	// so a file is evaluated once however often it is imported
+	modules = map[string]*object.Module{}
+	// importStack holds the absolute paths of the modules
+	// being loaded right now, to catch circular imports
+	importStack []string
+)
+
+// Reset forgets the modules imported so far, so they are read again
+// on the next import, along with any imports and calls left under way
+func Reset() {
+	modules = map[string]*object.Module{}
+	importStack = nil
+	callStack = nil
+}
+
+func evalImportStmt(node *ast.ImportStmt, env *object.Env) object.Obj {
+	path := resolveImportPath(node.Path.Value, node.Token.File)
+
+	stem := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	name, ok := moduleName(stem)
+	if !ok {
+		return NewErr(errs.BAD_MODULE_NAME, node.Path.Value, stem)
+	}
+
+	// the file being run is not loaded as a module, but
+	// importing it back is a circular import all the same
+	if len(importStack) == 0 && node.Token.File != nil && node.Token.File.Name != "" {
+		if main, err := filepath.Abs(node.Token.File.Name); err == nil {
+			importStack = append(importStack, main)
+			defer func() { importStack = importStack[:0] }()
+		}
+	}
+
+	mod := loadModule(name, path)
+	if isErr(mod) {
+		return mod
+	}
+
+	env.Set(name, mod)
+	return nil
+}
+
+// resolveImportPath finds the file which `path` refers to;
+// relative paths are relative to the directory of the importing file
+func resolveImportPath(path string, from *token.File) string {
+	if filepath.Ext(path) == "" {
+		path += ModuleExt
+	}
+
+	if !filepath.IsAbs(path) && from != nil && from.Name != "" {
+		path = filepath.Join(filepath.Dir(from.Name), path)
+	}
+
+	return filepath.Clean(path)
+}
+
+func loadModule(name string, path string) object.Obj {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return NewErr(errs.IMPORT_FAILED, path, errs.Reason(err))
+	}
+
+	if mod, ok := modules[abs]; ok {
+		return mod
+	}
+
+	for i, p := range importStack {
+		if p == abs {
+			cycle := append(append([]string{}, importStack[i:]...), abs)
+			for j := range cycle {
+				cycle[j] = filepath.Base(cycle[j])
+			}
+			return NewErr(errs.CIRCULAR_IMPORT, strings.Join(cycle, " -> "))
+		}
+	}
+
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return NewErr(errs.IMPORT_FAILED, path, errs.Reason(err))
+	}
+
+	// a module with a `# locale:` comment is read with its own packs,
+	// and the importer's are put back once it is loaded
+	modEnv := object.NewEnv()
+	if spec, ok := locale.Pragma(string(src)); ok {
+		prev := locale.Active()
+		if err := locale.Select(spec, filepath.Dir(path)); err != nil {
+			return NewErr(errs.IMPORT_FAILED, path, ": "+err.Error())
+		}
+		defer locale.Use(prev...)
+		modEnv = object.NewEnclosedEnv(spelledBuiltins())
+	}
+
+	lx := lexer.NewFileLexer(path, string(src))
+	ps := parser.NewParser(&lx)
+	prog := ps.ParseProg()
+
+	if len(ps.GetErrors()) != 0 {
+		msgs := []string{}
+		for _, e := range ps.GetErrors() {
+			msgs = append(msgs, e.String())
+		}
+		return NewErr(errs.MODULE_HAS_ERRORS, path, strings.Join(msgs, "\n\t"))
+	}
+
+	importStack = append(importStack, abs)
+	defer func() { importStack = importStack[:len(importStack)-1] }()
+
+	if res := Eval(prog, modEnv); isErr(res) {
+		return res
+	}
+
+	mod := &object.Module{Name: name, Path: path, Env: modEnv}
+	modules[abs] = mod
+	return mod
+}
+
+// spelledBuiltins returns a scope with the builtins under their
+// spellings in use, so the functions of a module with packs of its
+// own still find them when called with the importer's packs
+func spelledBuiltins() *object.Env {
+	env := object.NewEnv()
+	for _, s := range BuiltinSpellings() {
+		name, _ := locale.BuiltinName(s)
+		env.Set(s, builtins[name])
+	}
+	return env
+}
+
+func evalMemberExpr(node *ast.MemberExpr, env *object.Env) object.Obj {
+	left := Eval(node.Left, env)
+	if isErr(left) {
+		return left
+	}
+
+	mod, ok := left.(*object.Module)
+	if !ok {
+		return NewErr(errs.NOT_A_MODULE, left.Type())
+	}
+
+	val, ok := mod.Env.Own(node.Name.Value)
+	if !ok {
+		return NewErr(errs.NO_MEMBER, mod.Name, node.Name.Value)
+	}
+
+	return val
+}
+
+// moduleName returns the identifier which names the module read from
+// a file called `stem`, and false if the stem is not an identifier
+func moduleName(stem string) (string, bool) {
+	lx := lexer.NewLexer(stem)
+	tk := lx.NextToken()
+	return tk.Literal, tk.Type == token.IDENT && lx.NextToken().Type == token.EOF
+}
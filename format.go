@@ -0,0 +1,108 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"vabna/errs"
+	"vabna/format"
+	"vabna/locale"
+	"vabna/repl"
+)
+
+// formatCmd runs `vabna fmt [-check] [-w] [-keywords=bn|roman|en] [files]`
+// and returns the exit code
+func formatCmd(args []string) int {
+	fs := flag.NewFlagSet("fmt", flag.ContinueOnError)
+	check := fs.Bool("check", false, "")
+	write := fs.Bool("w", false, "")
+	keywords := fs.String("keywords", "", "")
+	parseFlags(fs, args)
+
+	opts := format.Options{}
+	if *keywords != "" {
+		pack, ok := locale.Find(*keywords)
+		if !ok {
+			complain(errs.CLI_BAD_SPELLING, *keywords)
+			return exitUsage
+		}
+		opts.Keywords = pack
+	}
+
+	if fs.NArg() == 0 {
+		src, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			complain(errs.CLI_CANNOT_READ, "-", errs.Reason(err))
+			return exitUsage
+		}
+
+		out, ok := formatSource("<stdin>", string(src), "", opts)
+		switch {
+		case !ok:
+			return exitSyntax
+		case *check && out != string(src):
+			fmt.Println("<stdin>")
+			return exitFailed
+		case !*check:
+			fmt.Print(out)
+		}
+		return exitOK
+	}
+
+	status := exitOK
+	for _, filename := range fs.Args() {
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			complain(errs.CLI_CANNOT_READ, filename, errs.Reason(err))
+			status = exitUsage
+			continue
+		}
+
+		out, ok := formatSource(filename, string(src), filepath.Dir(filename), opts)
+		if !ok {
+			status = exitSyntax
+			continue
+		}
+
+		switch {
+		case *check:
+			if out != string(src) {
+				fmt.Println(filename)
+				if status == exitOK {
+					status = exitFailed
+				}
+			}
+		case *write:
+			if out != string(src) {
+				if err := os.WriteFile(filename, []byte(out), 0644); err != nil {
+					complain(errs.CLI_CANNOT_WRITE, filename, errs.Reason(err))
+					status = exitUsage
+				}
+			}
+		default:
+			fmt.Print(out)
+		}
+	}
+
+	return status
+}
+
+// formatSource formats `src`, using the locale of its `# locale:`
+// comment if it has one; parse errors are printed to stderr
+func formatSource(filename string, src string, dir string, opts format.Options) (string, bool) {
+	restore, err := usePragma(src, dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return "", false
+	}
+	defer restore()
+
+	out, perrs := format.Source(filename, src, opts)
+	if len(perrs) != 0 {
+		repl.ShowParseErrors(os.Stderr, perrs)
+		return "", false
+	}
+	return out, true
+}
@@ -0,0 +1,512 @@
+// Package format prints Vabna programs in their canonical form.
+//
+// Blocks are indented with four spaces, operators have a space on
+// both sides, statements end with `;` and comments are kept where
+// they were. Numbers and strings are printed as they were written;
+// keywords keep their spelling unless Options asks for another one.
+package format
+
+import (
+	"strings"
+	"unicode"
+	"vabna/ast"
+	"vabna/errs"
+	"vabna/lexer"
+	"vabna/locale"
+	"vabna/parser"
+	"vabna/token"
+)
+
+// Indent is the indentation of one level of blocks
+const Indent = "    "
+
+// Options change how programs are printed
+type Options struct {
+	// Keywords is the pack to spell the keywords with; with nil
+	// every keyword keeps the spelling it has in the source
+	Keywords *locale.Pack
+}
+
+// Source formats the program `src` read from the file `name`. If
+// the program cannot be parsed it returns the parse errors instead.
+func Source(name string, src string, opts Options) (string, []errs.ParserError) {
+	lx := lexer.NewFileLexer(name, src)
+	ps := parser.NewParser(&lx)
+	prog := ps.ParseProg()
+	if perrs := ps.GetErrors(); len(perrs) != 0 {
+		return "", perrs
+	}
+
+	p := &printer{src: []rune(src), opts: opts, comments: lx.Comments(), first: true}
+	p.stmts(prog.Stmts)
+	p.flush(len(p.src) + 1)
+	if p.out.Len() != 0 {
+		p.out.WriteString("\n")
+	}
+
+	return p.out.String(), nil
+}
+
+type printer struct {
+	src      []rune
+	opts     Options
+	comments []token.Comment
+	next     int // the next comment to print
+
+	out    strings.Builder
+	indent int
+	// line is the source line of the last thing printed;
+	// a comment on the same line goes at the end of the line
+	line int
+	// first is set at the start of a block, where no blank line goes
+	first bool
+	// inline is set while printing a block on a single line
+	inline bool
+	// lost is set when a comment would be printed away from where it
+	// was written, like one in a condition going into the block after
+	lost bool
+}
+
+func (p *printer) write(s ...string) {
+	for _, str := range s {
+		p.out.WriteString(str)
+	}
+}
+
+// newline starts a new indented line for something on the
+// source line `line`, keeping one blank line if there were any
+// before it; with 0 there are no blank lines
+func (p *printer) newline(line int) {
+	if p.out.Len() != 0 {
+		p.write("\n")
+		if !p.first && line > p.line+1 {
+			p.write("\n")
+		}
+	}
+	p.write(strings.Repeat(Indent, p.indent))
+
+	p.first = false
+	if line > 0 {
+		p.line = line
+	}
+}
+
+// flush prints the comments which come before the offset `before`
+func (p *printer) flush(before int) {
+	for p.next < len(p.comments) && p.comments[p.next].Pos.Offset < before {
+		c := p.comments[p.next]
+		p.next += 1
+
+		if c.Pos.Line == p.line && p.out.Len() != 0 {
+			p.write(" ")
+			p.first = false
+		} else {
+			p.newline(c.Pos.Line)
+		}
+		p.write(strings.TrimRightFunc(c.Text, unicode.IsSpace))
+		p.line = c.EndLine()
+	}
+}
+
+func (p *printer) stmts(stmts []ast.Stmt) {
+	for i, s := range stmts {
+		p.flush(s.Pos().Offset)
+		p.newline(s.Pos().Line)
+
+		var next ast.Stmt
+		if i+1 < len(stmts) {
+			next = stmts[i+1]
+		}
+
+		m := p.mark()
+		p.lost = false
+		p.stmt(s, next)
+		if p.lost || p.next < len(p.comments) && p.comments[p.next].Pos.Offset < s.End().Offset {
+			// a comment is in a place the printer has none for, like
+			// in a condition; the statement is kept as it was written
+			// rather than moving the comment away from its code
+			printed := strings.TrimPrefix(p.out.String(), m.out)
+			p.restore(m)
+			p.write(string(p.src[s.Pos().Offset:s.End().Offset]))
+			if strings.HasSuffix(printed, ";") {
+				p.write(";")
+			}
+			for p.next < len(p.comments) && p.comments[p.next].Pos.Offset < s.End().Offset {
+				p.next += 1
+			}
+		}
+		p.lost = m.lost
+		p.line = s.End().Line
+	}
+}
+
+// mark is what the printer has done up to some point
+type mark struct {
+	out                 string
+	next, line, indent  int
+	first, inline, lost bool
+}
+
+func (p *printer) mark() mark {
+	return mark{p.out.String(), p.next, p.line, p.indent, p.first, p.inline, p.lost}
+}
+
+// restore undoes what was printed after `m`
+func (p *printer) restore(m mark) {
+	p.out.Reset()
+	p.out.WriteString(m.out)
+	p.next, p.line, p.indent, p.first, p.inline, p.lost = m.next, m.line, m.indent, m.first, m.inline, m.lost
+}
+
+// opens notes that the printer is at `open`, the start of a block or
+// a list, where comments before it would be lost if flushed
+func (p *printer) opens(open token.Token) {
+	if p.next < len(p.comments) && p.comments[p.next].Pos.Offset < open.Offset {
+		p.lost = true
+	}
+}
+
+func (p *printer) semicolon() {
+	if !p.inline {
+		p.write(";")
+	}
+}
+
+// stmt prints `s`; `next` is the statement after it, if any
+func (p *printer) stmt(s ast.Stmt, next ast.Stmt) {
+	switch s := s.(type) {
+	case *ast.LetStmt:
+		p.write(p.spell(s.Token), " ", s.Name.Value, " = ")
+		p.expr(s.Value, parser.LOWEST)
+		p.semicolon()
+	case *ast.ReturnStmt:
+		p.write(p.spell(s.Token))
+		if s.ReturnVal != nil {
+			p.write(" ")
+			p.expr(s.ReturnVal, parser.LOWEST)
+		}
+		p.semicolon()
+	case *ast.BreakStmt:
+		p.write(p.spell(s.Token))
+		p.semicolon()
+	case *ast.ContinueStmt:
+		p.write(p.spell(s.Token))
+		p.semicolon()
+	case *ast.ImportStmt:
+		p.write(p.spell(s.Token), " ", lexer.Raw(p.src, s.Path.Token))
+		p.semicolon()
+	case *ast.ExprStmt:
+		p.expr(s.Expr, parser.LOWEST)
+
+		// `যদি`, `যতক্ষণ` and `প্রতিটি` end with their block, unless
+		// the next statement would be read as a part of them
+		switch s.Expr.(type) {
+		case *ast.IfExpr, *ast.WhileExpr, *ast.ForEachExpr:
+			if n, ok := next.(*ast.ExprStmt); !ok || !startsWithOperator(n.Expr, parser.LOWEST) {
+				return
+			}
+		}
+		p.semicolon()
+	default:
+		p.write(s.String())
+	}
+}
+
+// precedence returns how tightly the parts of `e` are bound together
+func precedence(e ast.Expr) int {
+	switch e := e.(type) {
+	case *ast.InfixExpr:
+		return parser.Precedence(e.Token.Type)
+	case *ast.AssignExpr:
+		return parser.ASSIGN
+	case *ast.PrefixExpr:
+		return parser.PREFIX
+	case *ast.CallExpr:
+		return parser.CALL
+	case *ast.IndexExpr, *ast.MemberExpr:
+		return parser.INDEX
+	}
+	return parser.INDEX + 1
+}
+
+// operands returns the precedences the left and the right
+// operand of an infix expression need to go without parentheses
+func operands(e *ast.InfixExpr) (int, int) {
+	prec := precedence(e)
+	if e.Token.Type == token.POW {
+		// right associative; 2 ** 3 ** 2 == 2 ** (3 ** 2)
+		return prec + 1, prec
+	}
+	return prec, prec + 1
+}
+
+// startsWithOperator reports whether `e` printed where `ctx` is needed
+// starts with `(`, `[` or `-`, which can continue the expression before
+func startsWithOperator(e ast.Expr, ctx int) bool {
+	if precedence(e) < ctx {
+		return true
+	}
+
+	switch e := e.(type) {
+	case *ast.InfixExpr:
+		left, _ := operands(e)
+		return startsWithOperator(e.Left, left)
+	case *ast.AssignExpr:
+		return startsWithOperator(e.Target, parser.ASSIGN+1)
+	case *ast.CallExpr:
+		return startsWithOperator(e.Func, parser.CALL)
+	case *ast.IndexExpr:
+		return startsWithOperator(e.Left, parser.INDEX)
+	case *ast.MemberExpr:
+		return startsWithOperator(e.Left, parser.INDEX)
+	case *ast.PrefixExpr:
+		return e.Op == "-"
+	case *ast.ArrLit:
+		return true
+	}
+	return false
+}
+
+// expr prints `e` where an expression of the precedence `ctx`
+// is needed, in parentheses if it binds less tightly
+func (p *printer) expr(e ast.Expr, ctx int) {
+	if precedence(e) < ctx {
+		p.write("(")
+		p.expr(e, parser.LOWEST)
+		p.write(")")
+		return
+	}
+
+	switch e := e.(type) {
+	case *ast.Identifier:
+		p.write(e.Value)
+	case *ast.NumberLit:
+		p.write(lexer.Raw(p.src, e.Token))
+	case *ast.StringLit:
+		p.write(lexer.Raw(p.src, e.Token))
+	case *ast.InterpStringLit:
+		p.write(lexer.Raw(p.src, e.Token))
+	case *ast.Boolean:
+		p.write(p.spell(e.Token))
+	case *ast.PrefixExpr:
+		p.write(e.Op)
+		p.expr(e.Right, parser.PREFIX)
+	case *ast.InfixExpr:
+		left, right := operands(e)
+		p.expr(e.Left, left)
+		p.write(" ", p.spell(e.Token), " ")
+		p.expr(e.Right, right)
+	case *ast.AssignExpr:
+		p.expr(e.Target, parser.ASSIGN+1)
+		p.write(" ", e.Token.Literal, " ")
+		// assignments are right associative; `a = b = 1`
+		p.expr(e.Value, parser.ASSIGN)
+	case *ast.CallExpr:
+		p.expr(e.Func, parser.CALL)
+		p.items(e.Token, e.Close, len(e.Args), false, func(i int) (ast.Node, ast.Node) {
+			return e.Args[i], e.Args[i]
+		}, func(i int) {
+			p.expr(e.Args[i], parser.LOWEST)
+		})
+	case *ast.IndexExpr:
+		p.expr(e.Left, parser.INDEX)
+		p.write("[")
+		p.expr(e.Index, parser.LOWEST)
+		p.write("]")
+	case *ast.MemberExpr:
+		p.expr(e.Left, parser.INDEX)
+		p.write(".", e.Name.Value)
+	case *ast.ArrLit:
+		p.items(e.Token, e.Close, len(e.Elms), false, func(i int) (ast.Node, ast.Node) {
+			return e.Elms[i], e.Elms[i]
+		}, func(i int) {
+			p.expr(e.Elms[i], parser.LOWEST)
+		})
+	case *ast.HashLit:
+		p.items(e.Token, e.Close, len(e.Keys), true, func(i int) (ast.Node, ast.Node) {
+			return e.Keys[i], e.Pairs[e.Keys[i]]
+		}, func(i int) {
+			p.expr(e.Keys[i], parser.LOWEST)
+			p.write(": ")
+			p.expr(e.Pairs[e.Keys[i]], parser.LOWEST)
+		})
+	case *ast.IfExpr:
+		p.write(p.spell(e.Token), " (")
+		p.expr(e.Cond, parser.LOWEST)
+		p.write(") ", p.keyword(token.TAHOLE, e.TrueBlock.Token.Offset, e.Token), " ")
+		p.block(e.TrueBlock)
+		if e.ElseBlock != nil {
+			p.write(" ", p.keyword(token.ELSE, e.ElseBlock.Token.Offset, e.Token), " ")
+			p.block(e.ElseBlock)
+		}
+	case *ast.WhileExpr:
+		p.write(p.spell(e.Token), " (")
+		p.expr(e.Cond, parser.LOWEST)
+		p.write(") ")
+		p.block(e.StmtBlock)
+	case *ast.ForEachExpr:
+		p.write(p.spell(e.Token), " (")
+		for i, v := range e.Vars {
+			if i > 0 {
+				p.write(", ")
+			}
+			p.write(v.Value)
+		}
+		p.write(" ", p.keyword(token.IN, e.Iter.Pos().Offset, e.Token), " ")
+		p.expr(e.Iter, parser.LOWEST)
+		p.write(") ")
+		p.block(e.StmtBlock)
+	case *ast.FunctionLit:
+		if ekti := p.keyword(token.EKTI, e.Token.Offset, e.Token); ekti != "" {
+			p.write(ekti, " ")
+		}
+		p.write(p.spell(e.Token))
+		p.items(e.Open, e.Close, len(e.Params), false, func(i int) (ast.Node, ast.Node) {
+			return e.Params[i], e.Params[i]
+		}, func(i int) {
+			p.write(e.Params[i].Value)
+		})
+		p.write(" ")
+		p.block(e.Body)
+	default:
+		p.write(e.String())
+	}
+}
+
+// items prints the `n` elements of a list like an array, a hash or
+// the arguments of a call, each on a line
+// of its own if they were on more than one line in the source. `span`
+// returns the first and the last node of an element.
+func (p *printer) items(open, close token.Token, n int, trailingComma bool, span func(int) (ast.Node, ast.Node), item func(int)) {
+	p.write(open.Literal)
+
+	if n == 0 || p.inline || open.LineNo == close.LineNo {
+		for i := 0; i < n; i++ {
+			if i > 0 {
+				p.write(", ")
+			}
+			item(i)
+		}
+		p.write(close.Literal)
+		return
+	}
+
+	p.opens(open)
+	p.line = open.LineNo
+	p.indent += 1
+	p.first = true
+	for i := 0; i < n; i++ {
+		from, to := span(i)
+		p.flush(from.Pos().Offset)
+		p.newline(from.Pos().Line)
+		item(i)
+		if i < n-1 || trailingComma {
+			p.write(",")
+		}
+		p.line = to.End().Line
+	}
+	p.flush(close.Offset)
+	p.indent -= 1
+
+	p.newline(0)
+	p.write(close.Literal)
+	p.line = close.LineNo
+}
+
+// block prints `{ ... }`; on a single line if it was written so
+// and holds one statement, otherwise a statement on each line
+func (p *printer) block(bs *ast.BlockStmt) {
+	if p.isInline(bs) {
+		inline := p.inline
+		p.inline = true
+		p.write("{ ")
+		p.stmt(bs.Stmts[0], nil)
+		p.write(" }")
+		p.inline = inline
+		return
+	}
+
+	p.opens(bs.Token)
+	p.write("{")
+	start := p.out.Len()
+
+	p.line = bs.Token.LineNo
+	p.indent += 1
+	p.first = true
+	p.stmts(bs.Stmts)
+	p.flush(bs.Close.Offset)
+	p.indent -= 1
+	p.first = false
+
+	if p.out.Len() != start {
+		p.newline(0)
+	}
+	p.write("}")
+	p.line = bs.Close.LineNo
+}
+
+func (p *printer) isInline(bs *ast.BlockStmt) bool {
+	if p.inline {
+		return true
+	}
+	if len(bs.Stmts) != 1 || bs.Token.LineNo != bs.Close.LineNo {
+		return false
+	}
+	for _, c := range p.comments[p.next:] {
+		if c.Pos.Offset < bs.Close.Offset {
+			return false
+		}
+	}
+	return true
+}
+
+// spell returns how to write the token `tk`,
+// respelling keywords if the options ask for it
+func (p *printer) spell(tk token.Token) string {
+	if p.opts.Keywords == nil || !lexer.IsIdentifier(tk.Literal) {
+		return tk.Literal
+	}
+	return p.opts.Keywords.Keyword(tk.Type)
+}
+
+// keyword returns how to write the keyword `typ` which is not kept in
+// the tree, like `তাহলে`. It is looked for in the source right before
+// the offset `before`; if it is not there it is spelled in the same
+// language as `near`, a keyword of the same expression. EKTI is ""
+// where functions are written without it.
+func (p *printer) keyword(typ token.TokenType, before int, near token.Token) string {
+	if p.opts.Keywords != nil {
+		if typ == token.EKTI && p.opts.Keywords.Bare() {
+			return ""
+		}
+		return p.opts.Keywords.Keyword(typ)
+	}
+
+	end := before
+	for end > 0 && unicode.IsSpace(p.src[end-1]) {
+		end -= 1
+	}
+	start := end
+	for start > 0 && isWordChar(p.src[start-1]) {
+		start -= 1
+	}
+
+	lx := lexer.NewLexer(string(p.src[start:end]))
+	if tk := lx.NextToken(); tk.Type == typ && lx.NextToken().Type == token.EOF {
+		return tk.Literal
+	}
+
+	if typ == token.EKTI && token.BareFuncs[near.Literal] {
+		return ""
+	}
+	if pack, ok := locale.KeywordPack(near.Literal); ok {
+		return pack.Keyword(typ)
+	}
+	bn, _ := locale.Find("bn")
+	return bn.Keyword(typ)
+}
+
+func isWordChar(ch rune) bool {
+	return ch == '_' || ch == '\u200C' || ch == '\u200D' ||
+		unicode.In(ch, unicode.L, unicode.M, unicode.Nd, unicode.Nl, unicode.Pc)
+}
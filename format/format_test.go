@@ -0,0 +1,170 @@
+package format
+
+import (
+	"testing"
+	"vabna/locale"
+)
+
+func TestSource(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"ধরি  ক=১২ ;", "ধরি ক = ১২;\n"},
+		{"let x = (1+2)*3 - (4-5) - 6", "let x = (1 + 2) * 3 - (4 - 5) - 6;\n"},
+		{"(2 ** 3) ** 2; 2 ** (3 ** 2); (-2) ** 2; -(2 ** 2)", "(2 ** 3) ** 2;\n2 ** 3 ** 2;\n(-2) ** 2;\n-2 ** 2;\n"},
+		{"ক = খ = 1; (ক = 1) + 2; (ক + 1)(2)", "ক = খ = 1;\n(ক = 1) + 2;\n(ক + 1)(2);\n"},
+		{"দেখাও(\"ক\\n\" ,`খ\\n`, \"{ক+1}\")", "দেখাও(\"ক\\n\", `খ\\n`, \"{ক+1}\");\n"},
+		{
+			"ধরি যোগ=একটি কাজ(ক,খ){\n\n  ফেরাও ক+খ\n}",
+			"ধরি যোগ = একটি কাজ(ক, খ) {\n    ফেরাও ক + খ;\n};\n",
+		},
+		{"ধরি বর্গ = একটি কাজ(x) { x ** 2; }", "ধরি বর্গ = একটি কাজ(x) { x ** 2 };\n"},
+		{
+			"jodi (ক>1) tahole {দেখাও(ক)} nahole {\n}\nদেখাও(1)",
+			"jodi (ক > 1) tahole { দেখাও(ক) } nahole {}\nদেখাও(1);\n",
+		},
+		{"যদি (ক) তাহলে { 1 };\n-2", "যদি (ক) তাহলে { 1 };\n-2;\n"},
+		{"যদি (ক) তাহলে { 1 };\n[2]", "যদি (ক) তাহলে { 1 };\n[2];\n"},
+		{
+			"while (ক < 3) { ক += 1;\n\n\n  jodi (ক) tahole { break } }",
+			"while (ক < 3) {\n    ক += 1;\n\n    jodi (ক) tahole { break }\n}\n",
+		},
+		{
+			"প্রতিটি (ক,খ মধ্যে হ) {দেখাও(ক)}",
+			"প্রতিটি (ক, খ মধ্যে হ) { দেখাও(ক) }\n",
+		},
+		{
+			"ধরি হ = {\"ক\":1,\n\"খ\" : [1,2]}",
+			"ধরি হ = {\n    \"ক\": 1,\n    \"খ\": [1, 2],\n};\n",
+		},
+		{
+			"ধরি ক = [\n1,\n2]",
+			"ধরি ক = [\n    1,\n    2\n];\n",
+		},
+		{"আনো  \"গণিত.vab\"", "আনো \"গণিত.vab\";\n"},
+	}
+
+	for i, tt := range tests {
+		got, perrs := Source("", tt.input, Options{})
+		if len(perrs) != 0 {
+			t.Fatalf("tests[%d] -> Unexpected errors %v", i, perrs)
+		}
+		if got != tt.expected {
+			t.Fatalf("tests[%d] -> Expected=%q, Got=%q", i, tt.expected, got)
+		}
+	}
+}
+
+func TestComments(t *testing.T) {
+	input := `#!/usr/bin/vabna
+# ঘুমানো নিয়ে ছোট্ট উদাহরণ
+ধরি ঘুমানো = একটি কাজ(নাম){ # নাম দিলে
+    দেখাও(নাম + " ঘুমোচ্ছে" );  # বলে
+
+
+    /* কিছু না */
+};
+
+
+ঘুমানো("পলাশ"); # শেষ
+ধরি ব = {
+  "নাম": "পলাশ", # নাম
+  # বয়স
+  "বয়স": 20
+}
+# শেষে`
+
+	expected := `#!/usr/bin/vabna
+# ঘুমানো নিয়ে ছোট্ট উদাহরণ
+ধরি ঘুমানো = একটি কাজ(নাম) { # নাম দিলে
+    দেখাও(নাম + " ঘুমোচ্ছে"); # বলে
+
+    /* কিছু না */
+};
+
+ঘুমানো("পলাশ"); # শেষ
+ধরি ব = {
+    "নাম": "পলাশ", # নাম
+    # বয়স
+    "বয়স": 20,
+};
+# শেষে
+`
+
+	got, perrs := Source("", input, Options{})
+	if len(perrs) != 0 {
+		t.Fatalf("Unexpected errors %v", perrs)
+	}
+	if got != expected {
+		t.Fatalf("Expected=\n%s\nGot=\n%s", expected, got)
+	}
+
+	again, _ := Source("", got, Options{})
+	if again != got {
+		t.Fatalf("Formatting is not stable; Got=\n%s", again)
+	}
+}
+
+func TestInnerComments(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{
+			"দেখাও(1, # এক\n 2 /* দুই */, 3)",
+			"দেখাও(\n    1, # এক\n    2, /* দুই */\n    3\n);\n",
+		},
+		{
+			"ধরি যোগ = একটি কাজ(a, # প্রথম\n b) { a + b };",
+			"ধরি যোগ = একটি কাজ(\n    a, # প্রথম\n    b\n) { a + b };\n",
+		},
+		{
+			"যদি (1 < 2 /* সবসময় */) তাহলে { দেখাও(1) }",
+			"যদি (1 < 2 /* সবসময় */) তাহলে { দেখাও(1) }\n",
+		},
+	}
+
+	for i, tt := range tests {
+		got, perrs := Source("", tt.input, Options{})
+		if len(perrs) != 0 {
+			t.Fatalf("tests[%d] -> Unexpected errors %v", i, perrs)
+		}
+		if got != tt.expected {
+			t.Fatalf("tests[%d] -> Expected=%q, Got=%q", i, tt.expected, got)
+		}
+		if again, _ := Source("", got, Options{}); again != got {
+			t.Fatalf("tests[%d] -> Formatting is not stable; Got=%q", i, again)
+		}
+	}
+}
+
+func TestKeywords(t *testing.T) {
+	input := "let x = ekti kaj(a) { jodi (a ebong !sotto) tahole { a } nahole { for (i in a) { break; } } };"
+
+	tests := []struct {
+		pack     string
+		expected string
+	}{
+		{"bn", "ধরি x = একটি কাজ(a) { যদি (a এবং !সত্য) তাহলে { a } নাহলে { প্রতিটি (i মধ্যে a) { থামো } } };\n"},
+		{"roman", "dhori x = ekti kaj(a) { jodi (a ebong !sotto) tahole { a } nahole { protiti (i moddhe a) { thamo } } };\n"},
+		{"en", "let x = fn(a) { if (a and !true) then { a } else { for (i in a) { break } } };\n"},
+	}
+
+	for i, tt := range tests {
+		pack, _ := locale.Find(tt.pack)
+		got, perrs := Source("", input, Options{Keywords: pack})
+		if len(perrs) != 0 {
+			t.Fatalf("tests[%d] -> Unexpected errors %v", i, perrs)
+		}
+		if got != tt.expected {
+			t.Fatalf("tests[%d] -> Expected=%q, Got=%q", i, tt.expected, got)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	if _, perrs := Source("", "ধরি = 1;", Options{}); len(perrs) != 1 {
+		t.Fatalf("Expected 1 parse error, Got=%d", len(perrs))
+	}
+}
@@ -4,6 +4,5 @@ go 1.18
 
 require (
 	github.com/sirupsen/logrus v1.9.0
+	golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8
 )
-
-require golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
@@ -0,0 +1,105 @@
+package lexer
+
+import (
+	"strings"
+	"vabna/token"
+)
+
+// StringPart is a piece of an interpolated string; either
+// text or the source of an expression written inside `{}`
+type StringPart struct {
+	Text   string
+	IsExpr bool
+	Pos    token.Pos
+	// End is the position right after the part in the source
+	End token.Pos
+}
+
+// SplitString splits the literal of an ISTRING token into text
+// and expression parts. Escapes in the text parts are decoded.
+func SplitString(tk token.Token) []StringPart {
+	// the literal starts after the opening quote
+	l := NewLexerAt(tk.Literal, tk.Pos().Advance(1))
+
+	parts := []StringPart{}
+	var text strings.Builder
+	var textPos token.Pos
+
+	flush := func() {
+		if text.Len() > 0 {
+			parts = append(parts, StringPart{Text: text.String(), Pos: textPos, End: l.curPos()})
+			text.Reset()
+		}
+	}
+
+	for !l.AtEOF() {
+		if text.Len() == 0 {
+			textPos = l.curPos()
+		}
+
+		switch l.ch {
+		case '\\':
+			l.readChar()
+			l.readEscape(&text)
+		case '{':
+			flush()
+			end := matchBrace(l.input, l.pos)
+			if end < 0 {
+				end = len(l.input)
+			}
+			l.readChar()
+			from, pos := l.pos, l.curPos()
+			for l.pos < end {
+				l.readChar()
+			}
+			parts = append(parts, StringPart{Text: string(l.input[from:end]), IsExpr: true, Pos: pos, End: l.curPos()})
+		default:
+			text.WriteRune(l.ch)
+		}
+		l.readChar()
+	}
+	flush()
+
+	return parts
+}
+
+// matchBrace returns the index of the `}` closing the `{` at `open`,
+// skipping over strings in between, or -1 if it is never closed
+func matchBrace(input []rune, open int) int {
+	depth := 0
+	for i := open; i < len(input); i++ {
+		switch input[i] {
+		case '{':
+			depth += 1
+		case '}':
+			depth -= 1
+			if depth == 0 {
+				return i
+			}
+		case '"', '`':
+			if i = skipString(input, i); i < 0 {
+				return -1
+			}
+		}
+	}
+	return -1
+}
+
+// skipString returns the index of the quote closing
+// the string at `open`, or -1 if it is never closed
+func skipString(input []rune, open int) int {
+	quote := input[open]
+	for i := open + 1; i < len(input); i++ {
+		switch {
+		case input[i] == quote:
+			return i
+		case quote == '"' && input[i] == '\\':
+			i += 1
+		case quote == '"' && input[i] == '{':
+			if i = matchBrace(input, i); i < 0 {
+				return -1
+			}
+		}
+	}
+	return -1
+}
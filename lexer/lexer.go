@@ -1,8 +1,11 @@
 package lexer
 
 import (
+	"strconv"
+	"strings"
+	"unicode"
 	"vabna/token"
-
+	"vabna/trace"
 )
 
 type Lexer struct {
@@ -12,6 +15,17 @@ type Lexer struct {
 	ch      rune
 	line    int
 	column  int
+	file    *token.File
+	// offset of input in the file; non-zero for
+	// lexers reading a part of a bigger source
+	offset int
+
+	comments []token.Comment
+}
+
+// Comments returns all the comments read so far
+func (l *Lexer) Comments() []token.Comment {
+	return l.comments
 }
 
 func (l *Lexer) AtEOF() bool {
@@ -29,7 +43,21 @@ func getLen(inp string) int {
 */
 
 func NewLexer(input string) Lexer {
-	lexer := Lexer{input: []rune(input), line: 1}
+	return NewFileLexer("", input)
+}
+
+// NewFileLexer creates a lexer whose tokens remember
+// that they were read from the file `name`
+func NewFileLexer(name string, input string) Lexer {
+	lexer := Lexer{input: []rune(input), line: 1, file: token.NewFile(name, input)}
+	lexer.readChar()
+	return lexer
+}
+
+// NewLexerAt creates a lexer for `input`, a part of a
+// bigger source which starts at the position `pos`
+func NewLexerAt(input string, pos token.Pos) Lexer {
+	lexer := Lexer{input: []rune(input), line: pos.Line, column: pos.Column - 1, offset: pos.Offset, file: pos.File}
 	lexer.readChar()
 	return lexer
 }
@@ -37,6 +65,16 @@ func NewLexer(input string) Lexer {
 func (l *Lexer) readChar() {
 	//Advances lexer
 
+	if l.readPos > len(l.input) {
+		// already at the end
+		return
+	}
+
+	if l.ch == '\n' {
+		l.line += 1
+		l.column = 0
+	}
+
 	if l.readPos >= len(l.input) {
 		l.ch = 0
 	} else {
@@ -49,97 +87,154 @@ func (l *Lexer) readChar() {
 	l.column += 1
 }
 
-func (l *Lexer) NextToken() token.Token {
+func (l *Lexer) NextToken() (tk token.Token) {
 	// Get next token
 
-	var tk token.Token
-	l.eatWhitespace()
+	closed, commentPos := l.eatWhitespace()
+
+	line, col, offset := l.line, l.column, l.offset+l.pos
+	if !closed {
+		line, col, offset = commentPos.Line, commentPos.Column, commentPos.Offset
+	}
+	defer func() {
+		tk.LineNo = line
+		tk.Column = col
+		tk.Offset = offset
+		tk.File = l.file
+		tk.End = l.curPos()
+		trace.Token(tk)
+	}()
+
+	if !closed {
+		tk.Type = token.ILLEGAL
+		tk.Literal = "/*"
+		return tk
+	}
+
 	switch l.ch {
 
 	case '+':
-		tk = NewToken(token.PLUS, l.ch, l.line, l.column)
+		if l.peekChar() == '=' {
+			tk = l.readTwoCharToken(token.PLUS_EQ)
+		} else {
+			tk = NewToken(token.PLUS, l.ch, line, col)
+		}
 	case '-':
-		tk = NewToken(token.MINUS, l.ch, l.line, l.column)
+		if l.peekChar() == '=' {
+			tk = l.readTwoCharToken(token.MINUS_EQ)
+		} else {
+			tk = NewToken(token.MINUS, l.ch, line, col)
+		}
 	case '*':
-		tk = NewToken(token.MUL, l.ch, l.line, l.column)
+		if l.peekChar() == '*' {
+			tk = l.readTwoCharToken(token.POW)
+		} else if l.peekChar() == '=' {
+			tk = l.readTwoCharToken(token.MUL_EQ)
+		} else {
+			tk = NewToken(token.MUL, l.ch, line, col)
+		}
 	case '/':
-		tk = NewToken(token.DIV, l.ch, l.line, l.column)
+		if l.peekChar() == '=' {
+			tk = l.readTwoCharToken(token.DIV_EQ)
+		} else {
+			tk = NewToken(token.DIV, l.ch, line, col)
+		}
 	case '=':
 		if l.peekChar() == '=' {
-			ch := l.ch
-			l.readChar()
-			lit := string(ch) + string(l.ch)
-			tk = token.Token{Type: token.EQEQ, Literal: lit, LineNo: l.line, Column: l.column}
+			tk = l.readTwoCharToken(token.EQEQ)
+		} else {
+			tk = NewToken(token.EQ, l.ch, line, col)
+		}
+	case '%':
+		tk = NewToken(token.MOD, l.ch, line, col)
+	case '~':
+		if l.peekChar() == '/' {
+			tk = l.readTwoCharToken(token.FLOOR_DIV)
 		} else {
-			tk = NewToken(token.EQ, l.ch, l.line, l.column)
+			tk = NewToken(token.ILLEGAL, l.ch, line, col)
 		}
 	case ';':
-		tk = NewToken(token.SEMICOLON, l.ch, l.line, l.column)
+		tk = NewToken(token.SEMICOLON, l.ch, line, col)
 	case ',':
-		tk = NewToken(token.COMMA, l.ch, l.line, l.column)
+		tk = NewToken(token.COMMA, l.ch, line, col)
 	case '<':
 		if l.peekChar() == '=' {
-			ch := l.ch
-			l.readChar()
-			lit := string(ch) + string(l.ch)
-			tk = token.Token{Type: token.LTE, Literal: lit, LineNo: l.line, Column: l.column}
+			tk = l.readTwoCharToken(token.LTE)
 		} else {
-			tk = NewToken(token.LT, l.ch, l.line, l.column)
+			tk = NewToken(token.LT, l.ch, line, col)
 		}
 	case '>':
 		if l.peekChar() == '=' {
-			ch := l.ch
-			l.readChar()
-			lit := string(ch) + string(l.ch)
-			tk = token.Token{Type: token.GTE, Literal: lit, LineNo: l.line, Column: l.column}
-			//fmt.Println(tk)
+			tk = l.readTwoCharToken(token.GTE)
 		} else {
-			tk = NewToken(token.GT, l.ch, l.line, l.column)
+			tk = NewToken(token.GT, l.ch, line, col)
 		}
 	case '(':
-		tk = NewToken(token.LPAREN, l.ch, l.line, l.column)
+		tk = NewToken(token.LPAREN, l.ch, line, col)
 	case ')':
-		tk = NewToken(token.RPAREN, l.ch, l.line, l.column)
+		tk = NewToken(token.RPAREN, l.ch, line, col)
 	case '{':
-		tk = NewToken(token.LBRACE, l.ch, l.line, l.column)
+		tk = NewToken(token.LBRACE, l.ch, line, col)
 	case '}':
-		tk = NewToken(token.RBRACE, l.ch, l.line, l.column)
+		tk = NewToken(token.RBRACE, l.ch, line, col)
 	case '!':
 		if l.peekChar() == '=' {
-			ch := l.ch
-			l.readChar()
-			lit := string(ch) + string(l.ch)
-			tk = token.Token{Type: token.NOT_EQ, Literal: lit, LineNo: l.line, Column: l.column}
+			tk = l.readTwoCharToken(token.NOT_EQ)
+		} else {
+			tk = NewToken(token.EXC, l.ch, line, col)
+		}
+	case '&':
+		if l.peekChar() == '&' {
+			tk = l.readTwoCharToken(token.AND)
 		} else {
-			tk = NewToken(token.EXC, l.ch, l.line, l.column)
+			tk = NewToken(token.ILLEGAL, l.ch, line, col)
+		}
+	case '|':
+		if l.peekChar() == '|' {
+			tk = l.readTwoCharToken(token.OR)
+		} else {
+			tk = NewToken(token.ILLEGAL, l.ch, line, col)
+		}
+	case '"', '`':
+		quote, start := l.ch, l.pos
+		lit, interp, closed := l.readString(quote)
+		if closed && interp {
+			// the parser splits the raw text with SplitString
+			tk.Type = token.ISTRING
+			tk.Literal = string(l.input[start+1 : l.pos])
+		} else if closed {
+			tk.Type = token.STRING
+			tk.Literal = lit
+		} else {
+			tk.Type = token.ILLEGAL
+			tk.Literal = string(quote) + lit
 		}
-	case '"':
-		tk.Type = token.STRING
-		tk.Literal = l.readString()
 	case '[':
-		tk = NewToken(token.LS_BRACKET, l.ch, l.line, l.column)
+		tk = NewToken(token.LS_BRACKET, l.ch, line, col)
 	case ']':
-		tk = NewToken(token.RS_BRACKET, l.ch, l.line, l.column)
+		tk = NewToken(token.RS_BRACKET, l.ch, line, col)
 	case ':':
-		tk = NewToken(token.COLON, l.ch, l.line, l.column)
+		tk = NewToken(token.COLON, l.ch, line, col)
+	case '.':
+		tk = NewToken(token.DOT, l.ch, line, col)
 	case 0:
 		tk.Literal = ""
 		tk.Type = token.EOF
 
 	default:
-		if isLetter(l.ch) {
+		if isIdentStart(l.ch) {
 			tk.Literal = l.readIdent()
 			tk.Type = token.LookupIdent(tk.Literal)
 			return tk
 		} else if isDigit(l.ch) {
 			lit, _ := l.readNum()
 
-            //fmt.Println(lit)
+			//fmt.Println(lit)
 			tk.Literal = lit
-            tk.Type = token.NUM
+			tk.Type = token.NUM
 			return tk
 		} else {
-			tk = NewToken(token.ILLEGAL, l.ch, l.line, l.column)
+			tk = NewToken(token.ILLEGAL, l.ch, line, col)
 		}
 
 	}
@@ -149,27 +244,161 @@ func (l *Lexer) NextToken() token.Token {
 
 }
 
-func (l *Lexer) readString() string {
-	pos := l.pos + 1
+// readTwoCharToken reads tokens like `==` and `<=`,
+// leaving the lexer on the second character
+func (l *Lexer) readTwoCharToken(t token.TokenType) token.Token {
+	ch := l.ch
+	l.readChar()
+	return token.Token{Type: t, Literal: string(ch) + string(l.ch)}
+}
+
+// readString reads a string literal started by `quote`, leaving the
+// lexer on the closing quote. `"` strings understand escape sequences
+// and `{expr}` interpolation, "`" strings are raw. Returns whether
+// the string is interpolated and false if it is never closed.
+func (l *Lexer) readString(quote rune) (string, bool, bool) {
+	var out strings.Builder
+	interp := false
 
 	for {
 		l.readChar()
-		if l.ch == '"' || l.ch == 0 {
-			break
+
+		switch {
+		case l.ch == 0:
+			return out.String(), interp, false
+		case l.ch == quote:
+			return out.String(), interp, true
+		case l.ch == '\\' && quote == '"':
+			l.readChar()
+			l.readEscape(&out)
+		case l.ch == '{' && quote == '"':
+			end := matchBrace(l.input, l.pos)
+			if end < 0 {
+				end = len(l.input)
+			}
+			out.WriteString(string(l.input[l.pos:end]))
+			for l.pos < end {
+				l.readChar()
+			}
+			if l.ch == 0 {
+				return out.String(), interp, false
+			}
+			out.WriteRune(l.ch)
+			interp = true
+		default:
+			out.WriteRune(l.ch)
+		}
+	}
+}
+
+var escapes = map[rune]rune{
+	'n':  '\n',
+	't':  '\t',
+	'r':  '\r',
+	'0':  0,
+	'"':  '"',
+	'\\': '\\',
+	'{':  '{',
+	'}':  '}',
+}
+
+// readEscape writes the character escaped by `\`; the lexer is on
+// the character after the backslash. Unknown escapes are kept as they are.
+func (l *Lexer) readEscape(out *strings.Builder) {
+	if ch, ok := escapes[l.ch]; ok {
+		out.WriteRune(ch)
+		return
+	}
+
+	if l.ch == 'u' && l.peekChar() == '{' {
+		// \u{9A6} -> ভ
+		end := l.readPos + 1
+		for end < len(l.input) && end-l.readPos < 7 && isHexDigit(l.input[end]) {
+			end += 1
 		}
+		if end < len(l.input) && l.input[end] == '}' && end > l.readPos+1 {
+			code, err := strconv.ParseUint(string(l.input[l.readPos+1:end]), 16, 32)
+			if err == nil && code <= unicode.MaxRune {
+				for l.pos < end {
+					l.readChar()
+				}
+				out.WriteRune(rune(code))
+				return
+			}
+		}
+	}
+
+	if l.ch == 0 {
+		return
 	}
-	//fmt.Println(l.input[pos:l.pos])
-	return string(l.input[pos:l.pos])
+	out.WriteRune('\\')
+	out.WriteRune(l.ch)
 }
 
-func (l *Lexer) eatWhitespace() {
-	for l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r' {
-		if l.ch == '\n' {
-			l.line += 1
-			l.column = 1
+func isHexDigit(ch rune) bool {
+	return '0' <= ch && ch <= '9' || 'a' <= ch && ch <= 'f' || 'A' <= ch && ch <= 'F'
+}
+
+// eatWhitespace skips whitespace and comments. If a block comment
+// is never closed, it returns false and where the comment started.
+func (l *Lexer) eatWhitespace() (bool, token.Pos) {
+	for {
+		switch {
+		case l.ch == ' ' || l.ch == '\t' || l.ch == '\n' || l.ch == '\r':
+			l.readChar()
+		case l.ch == '#', l.ch == '/' && l.peekChar() == '/':
+			l.readLineComment()
+		case l.ch == '/' && l.peekChar() == '*':
+			if start, ok := l.readBlockComment(); !ok {
+				return false, start
+			}
+		default:
+			return true, token.Pos{}
 		}
+	}
+}
+
+func (l *Lexer) curPos() token.Pos {
+	return token.Pos{File: l.file, Offset: l.offset + l.pos, Line: l.line, Column: l.column}
+}
+
+// readLineComment reads a `#` or `//` comment up to the end of the line
+func (l *Lexer) readLineComment() {
+	start, from := l.curPos(), l.pos
+
+	for l.ch != '\n' && l.ch != 0 {
 		l.readChar()
 	}
+
+	text := string(l.input[from:l.pos])
+	l.comments = append(l.comments, token.Comment{Text: strings.TrimRight(text, "\r"), Pos: start})
+}
+
+// readBlockComment reads a `/* */` comment; block comments
+// can be nested like `/* a /* b */ c */`
+func (l *Lexer) readBlockComment() (token.Pos, bool) {
+	start, from := l.curPos(), l.pos
+	depth := 0
+
+	for l.ch != 0 {
+		if l.ch == '/' && l.peekChar() == '*' {
+			depth += 1
+			l.readChar()
+		} else if l.ch == '*' && l.peekChar() == '/' {
+			depth -= 1
+			l.readChar()
+		}
+		l.readChar()
+
+		if depth == 0 {
+			break
+		}
+	}
+
+	text := string(l.input[from:l.pos])
+	l.comments = append(l.comments, token.Comment{Text: text, Pos: start})
+
+	return start, depth == 0
 }
 
 func NewToken(tokType token.TokenType, ch rune, line int, col int) token.Token {
@@ -187,10 +416,10 @@ func (l *Lexer) readIdent() string {
 
 	pos := l.pos
 
-	for isLetter(l.ch) {
+	for isIdentContinue(l.ch) {
 		l.readChar()
 	}
-	return string(l.input[pos:l.pos])
+	return Normalize(string(l.input[pos:l.pos]))
 
 }
 
@@ -262,8 +491,59 @@ func (l *Lexer) peekChar() rune {
 	}
 }
 
-func isLetter(ch rune) bool {
-	return 'a' <= ch && ch <= 'z' || 'A' <= ch && ch <= 'Z' || ch == '_' || 'ঀ' <= ch && ch <= 'ৡ' || 'ৰ' <= ch && ch <= '৽'
+// IsIdentifier reports whether `name` is a single identifier
+// written the way the lexer reads it, in NFC form
+func IsIdentifier(name string) bool {
+	runes := []rune(name)
+	if len(runes) == 0 || !isIdentStart(runes[0]) {
+		return false
+	}
+	for _, ch := range runes[1:] {
+		if !isIdentContinue(ch) {
+			return false
+		}
+	}
+	return Normalize(name) == name
+}
+
+// Raw returns the text in `src` that `tk` was read from. Unlike the
+// literal, strings keep their quotes and escapes, numbers their digits
+// and names the code points they were typed with.
+func Raw(src []rune, tk token.Token) string {
+	start := tk.Offset
+	if start < 0 || start >= len(src) {
+		return tk.Literal
+	}
+
+	end := start + len([]rune(tk.Literal))
+	switch {
+	case tk.Type == token.STRING || tk.Type == token.ISTRING:
+		end = skipString(src, start) + 1
+	case isIdentStart(src[start]):
+		end = start + 1
+		for end < len(src) && isIdentContinue(src[end]) {
+			end += 1
+		}
+	}
+
+	if end <= start || end > len(src) {
+		return tk.Literal
+	}
+	return string(src[start:end])
+}
+
+// isIdentStart reports whether an identifier can begin with `ch`;
+// letters of any script and `_`, following Unicode's XID_Start
+func isIdentStart(ch rune) bool {
+	return ch == '_' || unicode.IsLetter(ch) || unicode.Is(unicode.Nl, ch)
+}
+
+// isIdentContinue reports whether `ch` can be inside an identifier;
+// after the first character vowel signs and other combining marks,
+// digits, ZWNJ and ZWJ (for conjuncts like র‍্য) can follow too
+func isIdentContinue(ch rune) bool {
+	return isIdentStart(ch) || unicode.In(ch, unicode.Mn, unicode.Mc, unicode.Nd, unicode.Pc) ||
+		ch == '\u200C' || ch == '\u200D'
 }
 
 func isDigit(ch rune) bool {
@@ -78,3 +78,285 @@ func TestNextToken(t *testing.T) {
     }
 
 }
+
+func TestTokenPositions(t *testing.T) {
+
+    inp := "ধরি নাম = \"পলাশ\";\n  নাম <= ১০"
+
+    tests := []struct{
+        expectedType token.TokenType
+        line int
+        col int
+        offset int
+    }{
+        {token.LET , 1 , 1 , 0},
+        {token.IDENT , 1 , 5 , 4},
+        {token.EQ , 1 , 9 , 8},
+        {token.STRING , 1 , 11 , 10},
+        {token.SEMICOLON , 1 , 17 , 16},
+        {token.IDENT , 2 , 3 , 20},
+        {token.LTE , 2 , 7 , 24},
+        {token.NUM , 2 , 10 , 27},
+    }
+
+    l := NewFileLexer("test.vab" , inp)
+
+    for i, tt := range tests{
+        tk := l.NextToken()
+
+        if tk.Type != tt.expectedType{
+            t.Fatalf("tests[%d] -> TokenType wrong -> Expected=%q, Got=%q" , i , tt.expectedType , tk.Type)
+        }
+
+        if tk.LineNo != tt.line || tk.Column != tt.col || tk.Offset != tt.offset{
+            t.Fatalf("tests[%d] -> Position wrong -> Expected=%d:%d(%d), Got=%d:%d(%d)" , i , tt.line , tt.col , tt.offset , tk.LineNo , tk.Column , tk.Offset)
+        }
+
+        if tk.File == nil || tk.File.Name != "test.vab"{
+            t.Fatalf("tests[%d] -> File wrong" , i)
+        }
+    }
+
+}
+
+func TestOperators(t *testing.T) {
+
+    inp := `= == += -= *= /= + - * / != <= >= && || এবং or % ~/ ** ~/ // মন্তব্য
+=`
+
+    tests := []struct{
+        expectedType token.TokenType
+        expectedLiteral string
+    }{
+        {token.EQ , "="},
+        {token.EQEQ , "=="},
+        {token.PLUS_EQ , "+="},
+        {token.MINUS_EQ , "-="},
+        {token.MUL_EQ , "*="},
+        {token.DIV_EQ , "/="},
+        {token.PLUS , "+"},
+        {token.MINUS , "-"},
+        {token.MUL , "*"},
+        {token.DIV , "/"},
+        {token.NOT_EQ , "!="},
+        {token.LTE , "<="},
+        {token.GTE , ">="},
+        {token.AND , "&&"},
+        {token.OR , "||"},
+        {token.AND , "এবং"},
+        {token.OR , "or"},
+        {token.MOD , "%"},
+        {token.FLOOR_DIV , "~/"},
+        {token.POW , "**"},
+        {token.FLOOR_DIV , "~/"},
+        {token.EQ , "="},
+        {token.EOF , ""},
+    }
+
+    l := NewLexer(inp)
+
+    for i, tt := range tests{
+        tk := l.NextToken()
+
+        if tk.Type != tt.expectedType{
+            t.Fatalf("tests[%d] -> TokenType wrong -> Expected=%q, Got=%q" , i , tt.expectedType , tk.Type)
+        }
+
+        if tk.Literal != tt.expectedLiteral{
+             t.Fatalf("tests[%d] -> Literal wrong -> Expected=%q, Got=%q" , i , tt.expectedLiteral , tk.Literal)
+        }
+    }
+
+}
+
+func TestComments(t *testing.T) {
+
+    inp := `# প্রথম মন্তব্য
+ধরি ক = 10 ~/ 3; // ভাগফল
+// আরেকটি # মন্তব্য
+/* বহু /* নেস্টেড */
+লাইনের মন্তব্য */ ক
+/* শেষ হয়নি`
+
+    tests := []struct{
+        expectedType token.TokenType
+        expectedLiteral string
+        line int
+    }{
+        {token.LET , "ধরি" , 2},
+        {token.IDENT , "ক" , 2},
+        {token.EQ , "=" , 2},
+        {token.NUM , "10" , 2},
+        {token.FLOOR_DIV , "~/" , 2},
+        {token.NUM , "3" , 2},
+        {token.SEMICOLON , ";" , 2},
+        {token.IDENT , "ক" , 5},
+        {token.ILLEGAL , "/*" , 6},
+        {token.EOF , "" , 6},
+    }
+
+    l := NewLexer(inp)
+
+    for i, tt := range tests{
+        tk := l.NextToken()
+
+        if tk.Type != tt.expectedType{
+            t.Fatalf("tests[%d] -> TokenType wrong -> Expected=%q, Got=%q" , i , tt.expectedType , tk.Type)
+        }
+
+        if tk.Literal != tt.expectedLiteral{
+             t.Fatalf("tests[%d] -> Literal wrong -> Expected=%q, Got=%q" , i , tt.expectedLiteral , tk.Literal)
+        }
+
+        if tk.LineNo != tt.line{
+             t.Fatalf("tests[%d] -> Line wrong -> Expected=%d, Got=%d" , i , tt.line , tk.LineNo)
+        }
+    }
+
+    comments := []string{"# প্রথম মন্তব্য" , "// ভাগফল" , "// আরেকটি # মন্তব্য" , "/* বহু /* নেস্টেড */\nলাইনের মন্তব্য */" , "/* শেষ হয়নি"}
+
+    if len(l.Comments()) != len(comments){
+        t.Fatalf("Expected %d comments, Got=%d" , len(comments) , len(l.Comments()))
+    }
+
+    for i, c := range l.Comments(){
+        if c.Text != comments[i]{
+            t.Fatalf("comments[%d] -> Expected=%q, Got=%q" , i , comments[i] , c.Text)
+        }
+    }
+
+}
+
+func TestStrings(t *testing.T) {
+
+    inp := "\"এক\\nদুই\\t\\\"তিন\\\"\\\\\" \"\\u{9AD}\\u{1F600}\\q\" `কাঁচা\\n\n\"লাইন\"` \"শেষ"
+
+    tests := []struct{
+        expectedType token.TokenType
+        expectedLiteral string
+        line int
+        col int
+    }{
+        {token.STRING , "এক\nদুই\t\"তিন\"\\" , 1 , 1},
+        {token.STRING , "ভ😀\\q" , 1 , 22},
+        {token.STRING , "কাঁচা\\n\n\"লাইন\"" , 1 , 43},
+        {token.ILLEGAL , "\"শেষ" , 2 , 9},
+        {token.EOF , "" , 2 , 13},
+    }
+
+    l := NewLexer(inp)
+
+    for i, tt := range tests{
+        tk := l.NextToken()
+
+        if tk.Type != tt.expectedType{
+            t.Fatalf("tests[%d] -> TokenType wrong -> Expected=%q, Got=%q" , i , tt.expectedType , tk.Type)
+        }
+
+        if tk.Literal != tt.expectedLiteral{
+             t.Fatalf("tests[%d] -> Literal wrong -> Expected=%q, Got=%q" , i , tt.expectedLiteral , tk.Literal)
+        }
+
+        if tk.LineNo != tt.line || tk.Column != tt.col{
+             t.Fatalf("tests[%d] -> Position wrong -> Expected=%d:%d, Got=%d:%d" , i , tt.line , tt.col , tk.LineNo , tk.Column)
+        }
+    }
+
+}
+
+func TestInterpolatedStrings(t *testing.T) {
+	inp := "\"বয়স {বয়স + 1} বছর\" \"\\{না}\" \"{f(\"}\")}\\n\""
+
+	l := NewLexer(inp)
+
+	tk := l.NextToken()
+	if tk.Type != token.ISTRING || tk.Literal != "বয়স {বয়স + 1} বছর" {
+		t.Fatalf("Expected interpolated string, Got=%q %q", tk.Type, tk.Literal)
+	}
+
+	parts := SplitString(tk)
+	expected := []struct {
+		text   string
+		isExpr bool
+		col    int
+	}{
+		{"বয়স ", false, 2},
+		{"বয়স + 1", true, 8},
+		{" বছর", false, 17},
+	}
+
+	if len(parts) != len(expected) {
+		t.Fatalf("Expected %d parts, Got=%d (%v)", len(expected), len(parts), parts)
+	}
+	for i, tt := range expected {
+		if parts[i].Text != tt.text || parts[i].IsExpr != tt.isExpr || parts[i].Pos.Column != tt.col {
+			t.Fatalf("parts[%d] -> Expected=%q %v %d, Got=%q %v %d", i, tt.text, tt.isExpr, tt.col, parts[i].Text, parts[i].IsExpr, parts[i].Pos.Column)
+		}
+	}
+
+	tk = l.NextToken()
+	if tk.Type != token.STRING || tk.Literal != "{না}" {
+		t.Fatalf("Expected plain string, Got=%q %q", tk.Type, tk.Literal)
+	}
+
+	tk = l.NextToken()
+	if tk.Type != token.ISTRING {
+		t.Fatalf("Expected interpolated string, Got=%q %q", tk.Type, tk.Literal)
+	}
+	parts = SplitString(tk)
+	if len(parts) != 2 || parts[0].Text != `f("}")` || parts[1].Text != "\n" {
+		t.Fatalf("Wrong parts %v", parts)
+	}
+
+	if tk = l.NextToken(); tk.Type != token.EOF {
+		t.Fatalf("Expected EOF, Got=%q %q", tk.Type, tk.Literal)
+	}
+}
+
+func TestIdentifiers(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{"x1 ক১ _খ২", []string{"x1", "ক১", "_খ২"}},
+		{"র\u200d্যাব", []string{"র\u200d্যাব"}},
+		{"বিজ্ঞান পৃথ\u200cক", []string{"বিজ্ঞান", "পৃথ\u200cক"}},
+		{"naïve мир λ", []string{"naïve", "мир", "λ"}},
+		// য় typed as one code point and as য + nukta
+		{"ব\u09dfস ব\u09af\u09bcস", []string{"ব\u09af\u09bcস", "ব\u09af\u09bcস"}},
+		// ো typed as one code point and as two parts
+		{"দ\u09cbকান দ\u09c7\u09beকান", []string{"দ\u09cbকান", "দ\u09cbকান"}},
+		// nukta is put before virama
+		{"ক\u09cd\u09bc", []string{"ক\u09bc\u09cd"}},
+		// only Bengali and Devanagari are normalized; é typed as
+		// one code point and as e + accent are different names
+		{"caf\u00e9 cafe\u0301", []string{"caf\u00e9", "cafe\u0301"}},
+	}
+
+	for i, tt := range tests {
+		l := NewLexer(tt.input)
+		for j, expected := range tt.expected {
+			tk := l.NextToken()
+			if tk.Type != token.IDENT || tk.Literal != expected {
+				t.Fatalf("tests[%d][%d] -> Expected=IDENT %q, Got=%s %q", i, j, expected, tk.Type, tk.Literal)
+			}
+		}
+		if tk := l.NextToken(); tk.Type != token.EOF {
+			t.Fatalf("tests[%d] -> Expected EOF, Got=%s %q", i, tk.Type, tk.Literal)
+		}
+	}
+
+	for _, inp := range []string{"া", "\u200d", "৳"} {
+		l := NewLexer(inp)
+		if tk := l.NextToken(); tk.Type != token.ILLEGAL {
+			t.Fatalf("%q -> Expected ILLEGAL, Got=%s %q", inp, tk.Type, tk.Literal)
+		}
+	}
+
+	for kw, typ := range token.Keywords {
+		l := NewLexer(kw)
+		if tk := l.NextToken(); tk.Type != typ || tk.Literal != kw {
+			t.Fatalf("keyword %q -> Expected=%s, Got=%s %q", kw, typ, tk.Type, tk.Literal)
+		}
+	}
+}
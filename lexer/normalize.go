@@ -0,0 +1,94 @@
+package lexer
+
+// The same Bengali word can be typed as different sequences of
+// code points depending on the keyboard; `য়` is either U+09DF or
+// U+09AF U+09BC and `ো` either U+09CB or U+09C7 U+09BE. Identifiers
+// are brought to their NFC form so that these spellings are the
+// same name. Only the Bengali and Devanagari parts of NFC are
+// needed for that, and they are small enough to be written here.
+
+// compositions are the pairs NFC joins into a single character
+var compositions = map[[2]rune]rune{
+	{0x09C7, 0x09BE}: 0x09CB, // ো
+	{0x09C7, 0x09D7}: 0x09CC, // ৌ
+	{0x0928, 0x093C}: 0x0929, // ऩ
+	{0x0930, 0x093C}: 0x0931, // ऱ
+	{0x0933, 0x093C}: 0x0934, // ऴ
+}
+
+// decompositions are the characters NFC always splits;
+// they are excluded from composition by Unicode
+var decompositions = map[rune][2]rune{
+	0x09DC: {0x09A1, 0x09BC}, // ড়
+	0x09DD: {0x09A2, 0x09BC}, // ঢ়
+	0x09DF: {0x09AF, 0x09BC}, // য়
+	0x0958: {0x0915, 0x093C}, // क़
+	0x0959: {0x0916, 0x093C}, // ख़
+	0x095A: {0x0917, 0x093C}, // ग़
+	0x095B: {0x091C, 0x093C}, // ज़
+	0x095C: {0x0921, 0x093C}, // ड़
+	0x095D: {0x0922, 0x093C}, // ढ़
+	0x095E: {0x092B, 0x093C}, // फ़
+	0x095F: {0x092F, 0x093C}, // य़
+}
+
+// combiningClass is the canonical combining class of the marks
+// which NFC may reorder; nukta comes before virama
+var combiningClass = map[rune]int{
+	0x09BC: 7, // nukta
+	0x09CD: 9, // virama
+	0x093C: 7,
+	0x094D: 9,
+}
+
+// Normalize returns the NFC form of the Bengali and
+// Devanagari text in `s`; other characters are kept as they are
+func Normalize(s string) string {
+	runes := []rune(s)
+
+	changed := false
+	for _, r := range runes {
+		if 0x0900 <= r && r <= 0x09FF {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return s
+	}
+
+	// decompose
+	out := make([]rune, 0, len(runes)+2)
+	for _, r := range runes {
+		if d, ok := decompositions[r]; ok {
+			out = append(out, d[0], d[1])
+		} else {
+			out = append(out, r)
+		}
+	}
+
+	// put marks next to each other in canonical order
+	for i := 1; i < len(out); i++ {
+		for j := i; j > 0; j-- {
+			a, b := combiningClass[out[j-1]], combiningClass[out[j]]
+			if b == 0 || a <= b {
+				break
+			}
+			out[j-1], out[j] = out[j], out[j-1]
+		}
+	}
+
+	// compose
+	res := out[:0]
+	for _, r := range out {
+		if n := len(res); n > 0 {
+			if c, ok := compositions[[2]rune{res[n-1], r}]; ok {
+				res[n-1] = c
+				continue
+			}
+		}
+		res = append(res, r)
+	}
+
+	return string(res)
+}
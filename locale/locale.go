@@ -0,0 +1,411 @@
+// Package locale holds the words Vabna programs are written with.
+//
+// A locale pack gives the spellings of the keywords and builtin
+// functions in one language. Packs for Bengali (bn), romanized
+// Bengali (roman) and English (en) are built in, and all three are
+// in use unless told otherwise; more packs can be loaded from JSON
+// files like the ones in packs/.
+package locale
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"vabna/errs"
+	"vabna/lexer"
+	"vabna/token"
+)
+
+//go:embed packs/*.json
+var packFiles embed.FS
+
+// Pack is a set of spellings for keywords and builtins
+type Pack struct {
+	Name string `json:"name"`
+	// Keywords maps keyword names like LET to their spellings
+	Keywords map[string][]string `json:"keywords"`
+	// Builtins maps builtin names like len to their spellings
+	Builtins map[string][]string `json:"builtins"`
+}
+
+// DefaultPacks are the names of the packs in use at start. roman
+// also has the English words the lexer always knew, like `let` and
+// `fn`; the rest of en has to be asked for, so that names like `if`
+// and `then` in older programs still work.
+var DefaultPacks = []string{"bn", "roman"}
+
+// keywordTypes are the keyword names a pack can use
+var keywordTypes = map[string]token.TokenType{
+	"FUNCTION": token.FUNC,
+	"LET":      token.LET,
+	"TRUE":     token.TRUE,
+	"FALSE":    token.FALSE,
+	"IF":       token.IF,
+	"ELSE":     token.ELSE,
+	"RETURN":   token.RETURN,
+	"HOLO":     token.HOLO,
+	"EKTI":     token.EKTI,
+	"TAHOLE":   token.TAHOLE,
+	"WHILE":    token.WHILE,
+	"FOREACH":  token.FOREACH,
+	"IN":       token.IN,
+	"BREAK":    token.BREAK,
+	"CONTINUE": token.CONTINUE,
+	"AND":      token.AND,
+	"OR":       token.OR,
+	"IMPORT":   token.IMPORT,
+}
+
+// keywordList are the names of keywordTypes, sorted
+var keywordList = func() []string {
+	names := make([]string, 0, len(keywordTypes))
+	for name := range keywordTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}()
+
+// optional are the keywords a set of packs can leave unspelled:
+// without EKTI functions are written like `fn(x) {}`, and HOLO has
+// no place in the grammar yet
+var optional = map[string]bool{"EKTI": true, "HOLO": true}
+
+// keywordNames maps the keyword types back to their names
+var keywordNames = func() map[token.TokenType]string {
+	names := map[token.TokenType]string{}
+	for name, typ := range keywordTypes {
+		names[typ] = name
+	}
+	return names
+}()
+
+var (
+	// builtinPacks are the packs shipped with the interpreter
+	builtinPacks = map[string]*Pack{}
+	// knownBuiltins are the builtin names the built-in packs spell
+	knownBuiltins = map[string]bool{}
+
+	// active are the packs in use
+	active []*Pack
+	// builtinNames maps every spelling in use to its builtin's name
+	builtinNames = map[string]string{}
+)
+
+func init() {
+	entries, err := packFiles.ReadDir("packs")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, e := range entries {
+		data, err := packFiles.ReadFile("packs/" + e.Name())
+		if err != nil {
+			panic(err)
+		}
+
+		p, err := Parse(data)
+		if err != nil {
+			panic(fmt.Sprintf("packs/%s: %s", e.Name(), err))
+		}
+
+		builtinPacks[p.Name] = p
+		for name := range p.Builtins {
+			knownBuiltins[name] = true
+		}
+	}
+
+	if err := Select(strings.Join(DefaultPacks, ","), ""); err != nil {
+		panic(err)
+	}
+}
+
+// Parse reads a pack from its JSON form
+func Parse(data []byte) (*Pack, error) {
+	p := &Pack{}
+	if err := json.Unmarshal(data, p); err != nil {
+		return nil, err
+	}
+
+	if p.Name == "" {
+		return nil, errors.New(errs.Msg(errs.LOCALE_NO_NAME))
+	}
+	return p, nil
+}
+
+// Load reads a pack from the JSON file at `path`
+func Load(path string) (*Pack, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.New(errs.Format(errs.LOCALE_BAD_FILE, path, errs.Reason(err)))
+	}
+
+	p, err := Parse(data)
+	if err != nil {
+		return nil, errors.New(errs.Format(errs.LOCALE_BAD_FILE, path, ": "+err.Error()))
+	}
+	return p, nil
+}
+
+// Find returns the built-in pack called `name`
+func Find(name string) (*Pack, bool) {
+	p, ok := builtinPacks[name]
+	return p, ok
+}
+
+// Resolve finds the packs listed in `spec`, like "bn,roman" or
+// "bn,hindi.json". Names of built-in packs are used as they are,
+// anything else is a file; relative paths are relative to `dir`.
+func Resolve(spec string, dir string) ([]*Pack, error) {
+	packs := []*Pack{}
+
+	for _, item := range strings.Split(spec, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		if p, ok := Find(item); ok {
+			packs = append(packs, p)
+			continue
+		}
+
+		path := item
+		if !filepath.IsAbs(path) && dir != "" {
+			path = filepath.Join(dir, path)
+		}
+		p, err := Load(path)
+		if err != nil {
+			return nil, err
+		}
+		packs = append(packs, p)
+	}
+
+	if len(packs) == 0 {
+		return nil, errors.New(errs.Format(errs.LOCALE_NO_PACKS, spec))
+	}
+	return packs, nil
+}
+
+// Select resolves `spec` like Resolve and puts the packs in use
+func Select(spec string, dir string) error {
+	packs, err := Resolve(spec, dir)
+	if err != nil {
+		return err
+	}
+	return Use(packs...)
+}
+
+// Use makes `packs` the only packs in use, replacing the keywords
+// the lexer knows and the names of the builtins. Nothing changes if
+// the packs are not valid or spell two different things the same way.
+func Use(packs ...*Pack) error {
+	keywords, builtins, err := merge(packs)
+	if err != nil {
+		return err
+	}
+
+	bare := map[string]bool{}
+	for _, p := range packs {
+		if p.Bare() {
+			for _, spelling := range p.Keywords["FUNCTION"] {
+				bare[spelling] = true
+			}
+		}
+	}
+
+	token.Keywords = keywords
+	token.BareFuncs = bare
+	builtinNames = builtins
+	active = packs
+	return nil
+}
+
+// Check reports the problems `packs` would have if used together
+func Check(packs ...*Pack) error {
+	_, _, err := merge(packs)
+	return err
+}
+
+func merge(packs []*Pack) (map[string]token.TokenType, map[string]string, error) {
+	keywords := map[string]token.TokenType{}
+	builtins := map[string]string{}
+	// meaning describes what every spelling stands for, for errors
+	meaning := map[string]string{}
+
+	add := func(p *Pack, spelling string, what string) error {
+		if !lexer.IsIdentifier(spelling) {
+			return errors.New(errs.Format(errs.LOCALE_BAD_SPELLING, p.Name, spelling, what))
+		}
+		if prev, ok := meaning[spelling]; ok && prev != what {
+			return errors.New(errs.Format(errs.LOCALE_CONFLICT, p.Name, spelling, prev, what))
+		}
+		meaning[spelling] = what
+		return nil
+	}
+
+	for _, p := range packs {
+		for _, name := range sortedKeys(p.Keywords) {
+			typ, ok := keywordTypes[name]
+			if !ok {
+				return nil, nil, errors.New(errs.Format(errs.LOCALE_UNKNOWN_KEYWORD, p.Name, name))
+			}
+			for _, spelling := range p.Keywords[name] {
+				if err := add(p, spelling, errs.Format(errs.LOCALE_KEYWORD, name)); err != nil {
+					return nil, nil, err
+				}
+				keywords[spelling] = typ
+			}
+		}
+
+		for _, name := range sortedKeys(p.Builtins) {
+			if !knownBuiltins[name] {
+				return nil, nil, errors.New(errs.Format(errs.LOCALE_UNKNOWN_BUILTIN, p.Name, name))
+			}
+			for _, spelling := range p.Builtins[name] {
+				if err := add(p, spelling, errs.Format(errs.LOCALE_BUILTIN, name)); err != nil {
+					return nil, nil, err
+				}
+				builtins[spelling] = name
+			}
+		}
+	}
+
+	// a keyword nobody spells cannot be written at all
+	spelled := map[token.TokenType]bool{}
+	for _, typ := range keywords {
+		spelled[typ] = true
+	}
+	for _, name := range keywordList {
+		if !spelled[keywordTypes[name]] && !optional[name] {
+			return nil, nil, errors.New(errs.Format(errs.LOCALE_UNSPELLED, name))
+		}
+	}
+
+	return keywords, builtins, nil
+}
+
+func sortedKeys(m map[string][]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Keyword returns how the pack spells the keyword `typ`. Packs
+// which do not spell it, like en for `তাহলে`, borrow the spelling
+// of the romanized pack.
+func (p *Pack) Keyword(typ token.TokenType) string {
+	name := keywordNames[typ]
+	for _, pack := range []*Pack{p, builtinPacks["roman"], builtinPacks["bn"]} {
+		if pack != nil && len(pack.Keywords[name]) > 0 {
+			return pack.Keywords[name][0]
+		}
+	}
+	return string(typ)
+}
+
+// Bare reports whether functions are written without EKTI in the
+// pack, like `fn(x) {}`: it spells FUNCTION but has no word for EKTI
+func (p *Pack) Bare() bool {
+	return len(p.Keywords["FUNCTION"]) > 0 && len(p.Keywords["EKTI"]) == 0
+}
+
+// Builtin returns how the pack spells the builtin `name`
+func (p *Pack) Builtin(name string) (string, bool) {
+	if len(p.Builtins[name]) == 0 {
+		return "", false
+	}
+	return p.Builtins[name][0], true
+}
+
+// KeywordPack returns the first pack in use which has `spelling`
+// as one of its keywords
+func KeywordPack(spelling string) (*Pack, bool) {
+	for _, p := range active {
+		for _, spellings := range p.Keywords {
+			for _, s := range spellings {
+				if s == spelling {
+					return p, true
+				}
+			}
+		}
+	}
+	return nil, false
+}
+
+// Active returns the packs in use
+func Active() []*Pack {
+	return active
+}
+
+// Spelling returns how the first pack in use which has a word for
+// the keyword `typ` spells it
+func Spelling(typ token.TokenType) string {
+	name := keywordNames[typ]
+	for _, p := range active {
+		if len(p.Keywords[name]) > 0 {
+			return p.Keywords[name][0]
+		}
+	}
+	return string(typ)
+}
+
+// BuiltinName returns the name of the builtin spelled `spelling`
+func BuiltinName(spelling string) (string, bool) {
+	name, ok := builtinNames[spelling]
+	return name, ok
+}
+
+// BuiltinSpellings returns every spelling of a builtin in use
+func BuiltinSpellings() []string {
+	res := make([]string, 0, len(builtinNames))
+	for s := range builtinNames {
+		res = append(res, s)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Builtins returns the names of all the builtins packs can spell
+func Builtins() []string {
+	res := make([]string, 0, len(knownBuiltins))
+	for name := range knownBuiltins {
+		res = append(res, name)
+	}
+	sort.Strings(res)
+	return res
+}
+
+// Pragma finds a `# locale: bn,roman` or `// locale: bn,roman`
+// comment among the comments at the top of `src` and returns the
+// packs it lists
+func Pragma(src string) (string, bool) {
+	for _, line := range strings.Split(src, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		var text string
+		if strings.HasPrefix(line, "//") {
+			text = strings.TrimPrefix(line, "//")
+		} else if strings.HasPrefix(line, "#") {
+			text = strings.TrimPrefix(line, "#")
+		} else {
+			break
+		}
+
+		text = strings.TrimSpace(text)
+		if key, value, ok := strings.Cut(text, ":"); ok && strings.TrimSpace(key) == "locale" {
+			return strings.TrimSpace(value), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,215 @@
+package locale
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"vabna/errs"
+	"vabna/token"
+)
+
+func useDefaults(t *testing.T) {
+	t.Helper()
+	if err := Select(strings.Join(DefaultPacks, ","), ""); err != nil {
+		t.Fatalf("Cannot use the default packs: %s", err)
+	}
+}
+
+func TestDefaultPacks(t *testing.T) {
+	var packs []*Pack
+	for _, name := range DefaultPacks {
+		packs = append(packs, builtinPacks[name])
+	}
+	merged, _, err := merge(packs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(merged, token.BaseKeywords) {
+		t.Fatalf("token.BaseKeywords should be the keywords of %v, Got=%v", DefaultPacks, token.BaseKeywords)
+	}
+
+	keywords := map[string]token.TokenType{
+		"ধরি":      token.LET,
+		"dhori":    token.LET,
+		"let":      token.LET,
+		"যতক্ষণ":   token.WHILE,
+		"jotokhon": token.WHILE,
+		"এবং":      token.AND,
+	}
+	for spelling, typ := range keywords {
+		if got := token.LookupIdent(spelling); got != typ {
+			t.Fatalf("keyword %q -> Expected=%s, Got=%s", spelling, typ, got)
+		}
+	}
+
+	// en is not in use, so its other words are names
+	for _, spelling := range []string{"if", "then", "return", "true", "new", "is"} {
+		if got := token.LookupIdent(spelling); got != token.IDENT {
+			t.Fatalf("%q should be a name, Got=%s", spelling, got)
+		}
+	}
+
+	for _, spelling := range []string{"দেখাও", "dekhau", "show"} {
+		if name, ok := BuiltinName(spelling); !ok || name != "show" {
+			t.Fatalf("builtin %q -> Expected=show, Got=%q", spelling, name)
+		}
+	}
+}
+
+func TestUse(t *testing.T) {
+	defer useDefaults(t)
+	defer errs.SetLang("bn")
+	errs.SetLang("en")
+
+	if err := Select("bn", ""); err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+
+	if token.LookupIdent("ধরি") != token.LET || token.LookupIdent("let") != token.IDENT {
+		t.Fatalf("Only the Bengali keywords should be in use")
+	}
+	if _, ok := BuiltinName("show"); ok {
+		t.Fatalf("Only the Bengali builtin names should be in use")
+	}
+
+	bad := &Pack{Name: "bad", Keywords: map[string][]string{"LET": {"যদি"}}}
+	if err := Use(bad, builtinPacks["bn"]); err == nil {
+		t.Fatalf("Expected a conflict error")
+	}
+	if token.LookupIdent("ধরি") != token.LET {
+		t.Fatalf("A failed Use must not change the keywords")
+	}
+
+	partial := &Pack{Name: "hi", Keywords: map[string][]string{"LET": {"मान"}}}
+	if err := Use(partial); err == nil || !strings.Contains(err.Error(), "keyword AND") {
+		t.Fatalf("Expected an error for the keywords hi does not spell, Got=%v", err)
+	}
+	for _, name := range DefaultPacks {
+		if err := Check(builtinPacks[name]); err != nil {
+			t.Fatalf("%s should work alone: %s", name, err)
+		}
+	}
+}
+
+func TestKeyword(t *testing.T) {
+	tests := []struct {
+		pack     string
+		typ      token.TokenType
+		expected string
+	}{
+		{"bn", token.LET, "ধরি"},
+		{"roman", token.IF, "jodi"},
+		{"en", token.WHILE, "while"},
+		{"en", token.TAHOLE, "then"},
+		// a pack without a word of its own for LET falls back to roman
+		{"hi", token.LET, "dhori"},
+	}
+
+	for i, tt := range tests {
+		pack, ok := Find(tt.pack)
+		if !ok {
+			pack = &Pack{Name: tt.pack}
+		}
+		if got := pack.Keyword(tt.typ); got != tt.expected {
+			t.Fatalf("tests[%d] -> Expected=%q, Got=%q", i, tt.expected, got)
+		}
+	}
+
+	if got := Spelling(token.LET); got != "ধরি" {
+		t.Fatalf("The first pack in use spells LET `ধরি`, Got=%q", got)
+	}
+
+	if p, ok := KeywordPack("jotokhon"); !ok || p.Name != "roman" {
+		t.Fatalf("`jotokhon` should be a keyword of roman")
+	}
+	if _, ok := KeywordPack("দেখাও"); ok {
+		t.Fatalf("`দেখাও` is not a keyword")
+	}
+}
+
+func TestCheck(t *testing.T) {
+	defer errs.SetLang("bn")
+	errs.SetLang("en")
+
+	tests := []struct {
+		pack     string
+		expected string
+	}{
+		{`{"keywords": {"LET": ["ধরো"]}}`, "no name"},
+		{`{"name": "x", "keywords": {"LOOP": ["ঘোরো"]}}`, "unknown keyword LOOP"},
+		{`{"name": "x", "builtins": {"print": ["ছাপো"]}}`, "unknown builtin print"},
+		{`{"name": "x", "keywords": {"LET": ["১ক"]}}`, "not a valid name"},
+		{`{"name": "x", "keywords": {"LET": ["ধরে নাও"]}}`, "not a valid name"},
+		{"{\"name\": \"x\", \"keywords\": {\"LET\": [\"ব\u09dfস\"]}}", "not a valid name"},
+		{`{"name": "x", "keywords": {"LET": ["যদি"]}}`, "cannot be both keyword IF and keyword LET"},
+		{`{"name": "x", "builtins": {"len": ["ধরি"]}}`, "cannot be both keyword LET and builtin len"},
+		{`{"name": "x", "builtins": {"len": ["যোগ"]}}`, "cannot be both builtin push and builtin len"},
+		{`{"name": "x", "keywords": {"LET": ["ধরো", "ধরি"]}, "builtins": {"len": ["মাপ"]}}`, ""},
+	}
+
+	for i, tt := range tests {
+		p, err := Parse([]byte(tt.pack))
+		if err == nil {
+			err = Check(builtinPacks["bn"], p)
+		}
+
+		if tt.expected == "" {
+			if err != nil {
+				t.Fatalf("tests[%d] -> Unexpected error %s", i, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.expected) {
+			t.Fatalf("tests[%d] -> Expected error %q, Got=%v", i, tt.expected, err)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	dir := t.TempDir()
+	pack := `{"name": "hi", "keywords": {"LET": ["मान"]}, "builtins": {"show": ["दिखाओ"]}}`
+	if err := os.WriteFile(filepath.Join(dir, "hi.json"), []byte(pack), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	packs, err := Resolve("bn, hi.json", dir)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	if len(packs) != 2 || packs[0].Name != "bn" || packs[1].Name != "hi" {
+		t.Fatalf("Wrong packs %v", packs)
+	}
+
+	if _, err := Resolve("bn,nope.json", dir); err == nil {
+		t.Fatalf("Expected an error for a missing pack")
+	}
+	if _, err := Resolve(" , ", dir); err == nil {
+		t.Fatalf("Expected an error for no packs")
+	}
+}
+
+func TestPragma(t *testing.T) {
+	tests := []struct {
+		src      string
+		expected string
+		ok       bool
+	}{
+		{"# locale: bn\nধরি ক = 1;", "bn", true},
+		{"#!/usr/bin/vabna\n\n#locale:bn, hi.json \nধরি ক = 1;", "bn, hi.json", true},
+		{"# গণনা\n# locale: roman\n", "roman", true},
+		{"ধরি ক = 1;\n# locale: bn", "", false},
+		{"# localization: bn", "", false},
+		{"// locale: en\nlet x = 5;", "en", true},
+		{"// গণনা\n#locale:roman", "roman", true},
+		{"/* locale: en */", "", false},
+	}
+
+	for i, tt := range tests {
+		got, ok := Pragma(tt.src)
+		if got != tt.expected || ok != tt.ok {
+			t.Fatalf("tests[%d] -> Expected=%q %v, Got=%q %v", i, tt.expected, tt.ok, got, ok)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package locale
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"vabna/errs"
+	"vabna/lexer"
+	"vabna/token"
+)
+
+// Translate rewrites the keywords and the builtin names of the program
+// `src` with the spellings of `to`. Everything else, names given by the
+// program, strings, comments and spaces, is kept as it is. Builtins
+// which `to` has no spelling for keep theirs.
+//
+// A builtin name the program gives to something of its own, like
+// `ধরি যোগ = 1;`, is not a builtin there and is not translated; a
+// spelling of `to` which is also a name in the program is an error.
+func Translate(src string, to *Pack) (string, error) {
+	runes := []rune(src)
+	lx := lexer.NewLexer(src)
+	toks := tokens(&lx)
+	names := declared(toks)
+
+	var out strings.Builder
+	last := 0
+
+	for i, tk := range toks {
+		var word string
+
+		if tk.Type == token.EKTI && to.Bare() {
+			// `to` writes functions without it, so it goes with the
+			// spaces after it
+			out.WriteString(string(runes[last:tk.Offset]))
+			last = tk.Offset + len([]rune(lexer.Raw(runes, tk)))
+			for last < len(runes) && unicode.IsSpace(runes[last]) {
+				last++
+			}
+			continue
+		}
+
+		if _, ok := keywordNames[tk.Type]; ok && lexer.IsIdentifier(tk.Literal) {
+			word = to.Keyword(tk.Type)
+			if tk.Type == token.FUNC && !to.Bare() && (i == 0 || toks[i-1].Type != token.EKTI) {
+				word = to.Keyword(token.EKTI) + " " + word
+			}
+		} else if tk.Type == token.IDENT && !names[tk.Literal] && (i == 0 || toks[i-1].Type != token.DOT) {
+			name, ok := BuiltinName(tk.Literal)
+			if !ok {
+				continue
+			}
+			if word, ok = to.Builtin(name); !ok {
+				continue
+			}
+		} else {
+			continue
+		}
+
+		raw := lexer.Raw(runes, tk)
+		if word == raw {
+			continue
+		}
+		if names[word] {
+			return "", errors.New(errs.Format(errs.LOCALE_NAME_TAKEN, tk.Pos(), raw, word))
+		}
+
+		out.WriteString(string(runes[last:tk.Offset]))
+		out.WriteString(word)
+		last = tk.Offset + len([]rune(raw))
+	}
+	out.WriteString(string(runes[last:]))
+
+	return out.String(), nil
+}
+
+// tokens reads all the tokens of `lx`, along with the tokens of the
+// expressions in interpolated strings right after the string itself
+func tokens(lx *lexer.Lexer) []token.Token {
+	toks := []token.Token{}
+
+	for {
+		tk := lx.NextToken()
+		if tk.Type == token.EOF {
+			return toks
+		}
+		toks = append(toks, tk)
+
+		if tk.Type == token.ISTRING {
+			for _, part := range lexer.SplitString(tk) {
+				if part.IsExpr {
+					sub := lexer.NewLexerAt(part.Text, part.Pos)
+					toks = append(toks, tokens(&sub)...)
+				}
+			}
+		}
+	}
+}
+
+// declared returns the names the program gives to variables,
+// parameters and loop variables
+func declared(toks []token.Token) map[string]bool {
+	names := map[string]bool{}
+
+	for i := 0; i < len(toks); i++ {
+		switch toks[i].Type {
+		case token.LET:
+			if i+1 < len(toks) && toks[i+1].Type == token.IDENT {
+				names[toks[i+1].Literal] = true
+			}
+		case token.FUNC, token.FOREACH:
+			if i+1 >= len(toks) || toks[i+1].Type != token.LPAREN {
+				continue
+			}
+			// `কাজ(ক, খ)` and `প্রতিটি (ক, খ মধ্যে ...)`
+			for j := i + 2; j < len(toks); j++ {
+				if toks[j].Type == token.IDENT {
+					names[toks[j].Literal] = true
+				} else if toks[j].Type != token.COMMA {
+					break
+				}
+			}
+		}
+	}
+
+	return names
+}
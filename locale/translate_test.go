@@ -0,0 +1,73 @@
+package locale
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTranslate(t *testing.T) {
+	// the results in en are read back with en in use
+	defer useDefaults(t)
+	if err := Select("bn,roman,en", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		input    string
+		to       string
+		expected string
+	}{
+		{"ধরি ক = দেখাও(আয়তন([1]));", "roman", "dhori ক = dekhau(ayoton([1]));"},
+		{"dhori  x=sotto ebong mittha # ধরি\n", "bn", "ধরি  x=সত্য এবং মিথ্যা # ধরি\n"},
+		{"let f = ekti fn(a) { jodi (a) tahole { show(\"let\") } }", "bn", "ধরি f = একটি কাজ(a) { যদি (a) তাহলে { দেখাও(\"let\") } }"},
+		// no pack has a spelling for `ইপচ`
+		{"যদি (ক && খ) তাহলে { ইপচ() }", "en", "if (ক && খ) then { ইপচ() }"},
+		{"protiti (k, v moddhe h) { continue }", "en", "for (k, v in h) { continue }"},
+		// names the program gives are not builtins
+		{"ধরি যোগ = 1; দেখাও(যোগ + 1);", "en", "let যোগ = 1; show(যোগ + 1);"},
+		{"একটি কাজ(দেখাও) { দেখাও }", "en", "fn(দেখাও) { দেখাও }"},
+		// en writes functions without `ekti`
+		{"ধরি f = একটি কাজ(ক) { ক }", "en", "let f = fn(ক) { ক }"},
+		{"let f = fn(ক) { ক }", "roman", "dhori f = ekti kaj(ক) { ক }"},
+		{"গণিত.দেখাও(1)", "en", "গণিত.দেখাও(1)"},
+		{"দেখাও(\"বয়স {আয়তন(ক) + 1}\")", "en", "show(\"বয়স {len(ক) + 1}\")"},
+	}
+
+	for i, tt := range tests {
+		pack, _ := Find(tt.to)
+		got, err := Translate(tt.input, pack)
+		if err != nil {
+			t.Fatalf("tests[%d] -> Unexpected error %s", i, err)
+		}
+		if got != tt.expected {
+			t.Fatalf("tests[%d] -> Expected=%q, Got=%q", i, tt.expected, got)
+		}
+
+		back, _ := Find("bn")
+		if round, _ := Translate(got, back); round != mustTranslate(t, tt.input, back) {
+			t.Fatalf("tests[%d] -> Translating back gave %q", i, round)
+		}
+	}
+}
+
+func mustTranslate(t *testing.T, src string, to *Pack) string {
+	t.Helper()
+	out, err := Translate(src, to)
+	if err != nil {
+		t.Fatalf("Unexpected error %s", err)
+	}
+	return out
+}
+
+func TestTranslateConflict(t *testing.T) {
+	defer useDefaults(t)
+	if err := Select("bn", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	en, _ := Find("en")
+	_, err := Translate("ধরি show = 1;\nদেখাও(show);", en)
+	if err == nil || !strings.Contains(err.Error(), "2:1") {
+		t.Fatalf("Expected a conflict error at 2:1, Got=%v", err)
+	}
+}
@@ -0,0 +1,65 @@
+package number
+
+import "fmt"
+
+type ErrorKind int
+
+const (
+	_ ErrorKind = iota
+	// DivByZero - dividing by zero
+	DivByZero
+	// NotANumber - the result is not a real number, like (-1) ** 0.5
+	NotANumber
+	// Infinite - the result is too large to be represented as a float
+	Infinite
+	// Overflow - the result needs more precision than MaxIntBits
+	Overflow
+	// UnknownOperator - the operator cannot be used with numbers
+	UnknownOperator
+)
+
+// MaxIntBits is the largest size of an integer an operation
+// may produce; anything bigger is reported as an Overflow
+const MaxIntBits = 1 << 20
+
+// Error is returned by number operations which
+// cannot produce a finite number
+type Error struct {
+	Kind ErrorKind
+	// Op is the operator which caused the error
+	Op string
+}
+
+func (e *Error) Error() string {
+	switch e.Kind {
+	case DivByZero:
+		return "division by zero"
+	case NotANumber:
+		return fmt.Sprintf("result of `%s` is not a real number", e.Op)
+	case Infinite:
+		return fmt.Sprintf("result of `%s` is infinite", e.Op)
+	case Overflow:
+		return fmt.Sprintf("result of `%s` is larger than %d bits", e.Op, MaxIntBits)
+	default:
+		return fmt.Sprintf("unknown operator for numbers `%s`", e.Op)
+	}
+}
+
+// Is makes errors.Is(err, ErrDivByZero) and
+// friends match errors of the same kind
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Kind == e.Kind
+}
+
+var (
+	ErrDivByZero       = &Error{Kind: DivByZero}
+	ErrNotANumber      = &Error{Kind: NotANumber}
+	ErrInfinite        = &Error{Kind: Infinite}
+	ErrOverflow        = &Error{Kind: Overflow}
+	ErrUnknownOperator = &Error{Kind: UnknownOperator}
+)
+
+func newError(kind ErrorKind, op string) *Error {
+	return &Error{Kind: kind, Op: op}
+}
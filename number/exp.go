@@ -0,0 +1,142 @@
+package number
+
+import (
+	"math"
+	"math/big"
+	"math/bits"
+)
+
+// guardBits are worked with beyond the precision of a result, so
+// that rounding in the series does not reach the digits returned
+const guardBits = 64
+
+// bigLog returns the natural logarithm of `x` > 0 at precision `prec`
+func bigLog(x *big.Float, prec uint) *big.Float {
+	// x = m × 2**k with ½ ≤ m < 1, so log x = log m + k log 2
+	m := new(big.Float).SetPrec(prec)
+	k := x.MantExp(m)
+
+	res := atanhLog(m, prec)
+	if k != 0 {
+		ln2 := atanhLog(new(big.Float).SetPrec(prec).SetFloat64(0.5), prec)
+		ln2.Neg(ln2)
+		res.Add(res, ln2.Mul(ln2, new(big.Float).SetPrec(prec).SetInt64(int64(k))))
+	}
+	return res
+}
+
+// atanhLog returns log m = 2 atanh((m-1)/(m+1)) for ½ ≤ m < 1,
+// where the series gains at least three bits with every term
+func atanhLog(m *big.Float, prec uint) *big.Float {
+	one := new(big.Float).SetPrec(prec).SetInt64(1)
+	z := new(big.Float).SetPrec(prec).Sub(m, one)
+	z.Quo(z, new(big.Float).SetPrec(prec).Add(m, one))
+
+	z2 := new(big.Float).SetPrec(prec).Mul(z, z)
+	sum := new(big.Float).SetPrec(prec).Set(z)
+	pow := new(big.Float).SetPrec(prec).Set(z)
+	term := new(big.Float).SetPrec(prec)
+	for n := int64(3); sum.Sign() != 0; n += 2 {
+		pow.Mul(pow, z2)
+		term.Quo(pow, new(big.Float).SetPrec(prec).SetInt64(n))
+		if term.Sign() == 0 || term.MantExp(nil) < sum.MantExp(nil)-int(prec) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	return sum.Mul(sum, new(big.Float).SetPrec(prec).SetInt64(2))
+}
+
+// squarings is how many times the argument of exp is halved
+// before its series is summed, and the result squared after
+const squarings = 16
+
+// bigExp returns e**y at precision `prec`; the result
+// is ±0 or ±Inf when it is out of the range of big.Float
+func bigExp(y *big.Float, prec uint) *big.Float {
+	prec += squarings
+	if y.Sign() == 0 {
+		return new(big.Float).SetPrec(prec).SetInt64(1)
+	}
+
+	// y = k log 2 + r with |r| ≤ ½ log 2, so e**y = e**r × 2**k
+	ln2 := bigLog(new(big.Float).SetPrec(prec).SetInt64(2), prec)
+	q := new(big.Float).SetPrec(prec).Quo(y, ln2)
+	if q.MantExp(nil) > 32 {
+		// far beyond MaxExp either way
+		if y.Sign() > 0 {
+			return new(big.Float).SetPrec(prec).SetInf(false)
+		}
+		return new(big.Float).SetPrec(prec)
+	}
+	q.Add(q, new(big.Float).SetFloat64(math.Copysign(0.5, float64(q.Sign()))))
+	ik, _ := q.Int64()
+	r := new(big.Float).SetPrec(prec).SetInt64(ik)
+	r.Sub(y, r.Mul(r, ln2))
+	r.SetMantExp(r, -squarings)
+
+	sum := new(big.Float).SetPrec(prec).SetInt64(1)
+	term := new(big.Float).SetPrec(prec).SetInt64(1)
+	for n := int64(1); ; n++ {
+		term.Mul(term, r)
+		term.Quo(term, new(big.Float).SetPrec(prec).SetInt64(n))
+		if term.Sign() == 0 || term.MantExp(nil) < -int(prec) {
+			break
+		}
+		sum.Add(sum, term)
+	}
+	for i := 0; i < squarings; i++ {
+		sum.Mul(sum, sum)
+	}
+
+	if ik > math.MaxInt32 || ik < math.MinInt32 {
+		if ik > 0 {
+			return sum.SetInf(false)
+		}
+		return sum.SetInt64(0)
+	}
+	return sum.SetMantExp(sum, int(ik))
+}
+
+// bigPow returns a**b for a ≠ 0 at the precision of its operands,
+// as e**(b log |a|); the sign is that of `a` when b is odd
+func bigPow(a *big.Float, b *big.Float) *big.Float {
+	prec := a.Prec()
+	if b.Prec() > prec {
+		prec = b.Prec()
+	}
+
+	// the error of b log |a| grows with its size, and so with
+	// the exponents of both
+	ea, eb := a.MantExp(nil), b.MantExp(nil)
+	work := prec + guardBits + uint(bits.Len(uint(abs(ea))))
+	if eb > 0 {
+		work += uint(eb)
+	}
+
+	mag := new(big.Float).SetPrec(work).Abs(a)
+	y := bigLog(mag, work)
+	y.Mul(y, new(big.Float).SetPrec(work).Set(b))
+	res := bigExp(y, work)
+
+	if a.Sign() < 0 && isOdd(b) {
+		res.Neg(res)
+	}
+	return res.SetPrec(prec)
+}
+
+// isOdd tells if `b` is an odd integer
+func isOdd(b *big.Float) bool {
+	if !b.IsInt() {
+		return false
+	}
+	i, _ := b.Int(nil)
+	return i.Bit(0) == 1
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
@@ -0,0 +1,88 @@
+package number
+
+import (
+	"strings"
+)
+
+// Numerals are the digits numbers are printed with
+type Numerals int
+
+const (
+	// EnglishNumerals - 0123456789
+	EnglishNumerals Numerals = iota
+	// BengaliNumerals - ০১২৩৪৫৬৭৮৯
+	BengaliNumerals
+)
+
+// OutputNumerals are the digits used whenever the
+// interpreter turns a number into text
+var OutputNumerals = EnglishNumerals
+
+// String returns `n` written with the OutputNumerals
+func (n Number) String() string {
+	return Localize(n.Value.String())
+}
+
+// Localize rewrites the ASCII digits of `s` with the OutputNumerals
+func Localize(s string) string {
+	if OutputNumerals != BengaliNumerals {
+		return s
+	}
+
+	return strings.Map(func(r rune) rune {
+		if '0' <= r && r <= '9' {
+			return '০' + (r - '0')
+		}
+		return r
+	}, s)
+}
+
+// Format writes `n` with lakh/crore digit grouping, like
+// 1,23,45,678.5, using the OutputNumerals. If `places` is not
+// negative, exactly that many digits are kept after the point.
+func Format(n Number, places int) string {
+	var s string
+
+	switch {
+	case places < 0:
+		s = n.Value.String()
+	case n.IsInt && places == 0:
+		s = n.Value.String()
+	default:
+		s = toFloat(n).Text('f', places)
+	}
+
+	return Localize(Group(s))
+}
+
+// Group inserts commas in the integer part of the number
+// `s` the Indian way; the last three digits form a group,
+// then every two digits. Exponent forms are left alone.
+func Group(s string) string {
+	if strings.ContainsAny(s, "eE") {
+		return s
+	}
+
+	sign := ""
+	if strings.HasPrefix(s, "-") || strings.HasPrefix(s, "+") {
+		sign, s = s[:1], s[1:]
+	}
+
+	frac := ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		s, frac = s[:i], s[i:]
+	}
+
+	if len(s) <= 3 {
+		return sign + s + frac
+	}
+
+	head, groups := s[:len(s)-3], []string{s[len(s)-3:]}
+	for len(head) > 2 {
+		groups = append([]string{head[len(head)-2:]}, groups...)
+		head = head[:len(head)-2]
+	}
+	groups = append([]string{head}, groups...)
+
+	return sign + strings.Join(groups, ",") + frac
+}
@@ -1,16 +1,22 @@
 package number
 
 import (
+	"math"
 	"math/big"
 	"vabna/token"
 )
 
 func MakeInt(a int64) Number{
-    return Number{ Value: &IntNumber{ Value: *big.NewInt(a) } }
+    return Number{ Value: &IntNumber{ Value: *big.NewInt(a) } , IsInt: true }
+}
+
+// MakeUint makes an integer of `a`, which may be above MaxInt64
+func MakeUint(a uint64) Number {
+	return Number{Value: &IntNumber{Value: *new(big.Int).SetUint64(a)}, IsInt: true}
 }
 
 func MakeFloat(a float64) Number{
-    return Number{Value: &FloatNumber{ Value: *big.NewFloat(a) }  }
+    return Number{Value: &FloatNumber{ Value: *big.NewFloat(a) } , IsInt: false }
 }
 
 func MakeNeg(a Number) Number{
@@ -38,7 +44,12 @@ func GetAsInt(a Number) (int64, bool){
     }else{
         fa := a.Value.(*FloatNumber).Value
 
-        a,_ := fa.Int64()
+        a,acc := fa.Int64()
+
+        // out of the range of int64
+        if (a == math.MaxInt64 && acc == big.Below) || (a == math.MinInt64 && acc == big.Above) {
+            return int64(0), false
+        }
 
         return a,true
     }
@@ -121,110 +132,209 @@ func IntIntCompare(op string, a big.Int, b big.Int) bool {
 	return false
 }
 
-func NumberOperation(op string, n Number, x Number) (Number, bool, bool) {
-
-	var fb *big.Float
-	var fa *big.Float
-	var val *big.Float
-	switch n.GetType() {
-	case "FLOAT":
-		fa = &n.Value.(*FloatNumber).Value
-		//var fb  *big.Float
-
-		//var val *big.Float
-		if x.IsInt {
-			b := x.Value.(*IntNumber).Value
-
-			fb = new(big.Float).SetInt(&b)
-		} else {
-			fb = &x.Value.(*FloatNumber).Value
-		}
-
-		switch op {
-		case token.PLUS:
-			val = new(big.Float).Add(fa, fb)
-		case token.MINUS:
-			val = new(big.Float).Sub(fa, fb)
-		case token.MUL:
-			val = new(big.Float).Mul(fa, fb)
-		case token.DIV:
-			val = new(big.Float).Quo(fa, fb)
-		case token.GT:
-			return Number{}, FloatFloatCompare(token.GT, *fa, *fb), true
-		case token.GTE:
-			return Number{}, FloatFloatCompare(token.GTE, *fa, *fb), true
-		case token.LT:
-			return Number{}, FloatFloatCompare(token.LT, *fa, *fb), true
-		case token.LTE:
-			return Number{}, FloatFloatCompare(token.LTE, *fa, *fb), true
-		case token.NOT_EQ:
-			return Number{}, FloatFloatCompare(token.NOT_EQ, *fa, *fb), true
-		case token.EQEQ:
-			return Number{}, FloatFloatCompare(token.EQEQ, *fa, *fb), true
-
-		}
-		return Number{Value: &FloatNumber{Value: *val}, IsInt: false}, false, true
-	case "INT":
-		ia := n.Value.(*IntNumber).Value
+// NumberOperation applies the operator `op` to `n` and `x`.
+// Arithmetic operators return the resulting number, comparison
+// operators return a zero Number and the result of the comparison.
+// Results which are not finite numbers are returned as *Error.
+func NumberOperation(op string, n Number, x Number) (res Number, cmp bool, err error) {
+
+	defer func() {
+		// big.Float panics on operations like Inf - Inf
+		if r := recover(); r != nil {
+			if _, ok := r.(big.ErrNaN); !ok {
+				panic(r)
+			}
+			res, cmp, err = Number{}, false, newError(NotANumber, op)
+		}
+	}()
 
-		if x.IsInt {
+	switch op {
+	case token.GT, token.GTE, token.LT, token.LTE, token.NOT_EQ, token.EQEQ:
+		if n.IsInt && x.IsInt {
+			ia := n.Value.(*IntNumber).Value
 			ib := x.Value.(*IntNumber).Value
-			var val *big.Int
-			switch op {
-			case token.PLUS:
-				val = new(big.Int).Add(&ia, &ib)
-			case token.MINUS:
-				val = new(big.Int).Sub(&ia, &ib)
-			case token.MUL:
-				val = new(big.Int).Mul(&ia, &ib)
-			case token.DIV:
-				val = new(big.Int).Div(&ia, &ib)
-			case token.GT:
-				return Number{}, IntIntCompare(token.GT, ia, ib), true
-			case token.GTE:
-				return Number{}, IntIntCompare(token.GTE, ia, ib), true
-			case token.LT:
-				return Number{}, IntIntCompare(token.LT, ia, ib), true
-			case token.LTE:
-				return Number{}, IntIntCompare(token.LTE, ia, ib), true
-			case token.NOT_EQ:
-				return Number{}, IntIntCompare(token.NOT_EQ, ia, ib), true
-			case token.EQEQ:
-				return Number{}, IntIntCompare(token.EQEQ, ia, ib), true
+			return Number{}, IntIntCompare(op, ia, ib), nil
+		}
+		return Number{}, FloatFloatCompare(op, *toFloat(n), *toFloat(x)), nil
+	}
+
+	if n.IsInt && x.IsInt {
+		ia := n.Value.(*IntNumber).Value
+		ib := x.Value.(*IntNumber).Value
+		res, err = intOperation(op, &ia, &ib)
+	} else {
+		res, err = floatOperation(op, toFloat(n), toFloat(x))
+	}
+
+	if err == nil {
+		err = checkResult(op, res)
+	}
+	if err != nil {
+		return Number{}, false, err
+	}
+	return res, false, nil
+}
+
+// checkResult reports results which are infinite
+// or too large to be worked with as errors
+func checkResult(op string, n Number) error {
+	if n.IsInt {
+		i := n.Value.(*IntNumber).Value
+		if i.BitLen() > MaxIntBits {
+			return newError(Overflow, op)
+		}
+		return nil
+	}
+
+	f := n.Value.(*FloatNumber).Value
+	if f.IsInf() {
+		return newError(Infinite, op)
+	}
+	return nil
+}
 
+func toFloat(n Number) *big.Float {
+	if n.IsInt {
+		i := n.Value.(*IntNumber).Value
+		return new(big.Float).SetInt(&i)
+	}
+	f := n.Value.(*FloatNumber).Value
+	return &f
+}
+
+func intNumber(i *big.Int) Number {
+	return Number{Value: &IntNumber{Value: *i}, IsInt: true}
+}
+
+func floatNumber(f *big.Float) Number {
+	return Number{Value: &FloatNumber{Value: *f}, IsInt: false}
+}
+
+func intOperation(op string, a *big.Int, b *big.Int) (Number, error) {
+	switch op {
+	case token.PLUS:
+		return intNumber(new(big.Int).Add(a, b)), nil
+	case token.MINUS:
+		return intNumber(new(big.Int).Sub(a, b)), nil
+	case token.MUL:
+		return intNumber(new(big.Int).Mul(a, b)), nil
+	case token.DIV:
+		if b.Sign() == 0 {
+			return Number{}, newError(DivByZero, op)
+		}
+		return intNumber(new(big.Int).Div(a, b)), nil
+	case token.FLOOR_DIV, token.MOD:
+		if b.Sign() == 0 {
+			return Number{}, newError(DivByZero, op)
+		}
+		q, m := floorDivMod(a, b)
+		if op == token.MOD {
+			return intNumber(m), nil
+		}
+		return intNumber(q), nil
+	case token.POW:
+		if b.Sign() >= 0 {
+			// estimate the size first; `Exp` would happily
+			// try to fill the whole memory
+			if a.CmpAbs(big.NewInt(1)) > 0 &&
+				(!b.IsInt64() || b.Int64() > MaxIntBits/int64(a.BitLen()-1)) {
+				return Number{}, newError(Overflow, op)
 			}
+			return intNumber(new(big.Int).Exp(a, b, nil)), nil
+		}
+		// a ** -n == 1 / (a ** n)
+		return floatOperation(op, new(big.Float).SetInt(a), new(big.Float).SetInt(b))
+	}
+
+	return Number{}, newError(UnknownOperator, op)
+}
+
+// floorDivMod returns the quotient rounded towards negative infinity
+// and the matching remainder, which has the same sign as `b`
+func floorDivMod(a *big.Int, b *big.Int) (*big.Int, *big.Int) {
+	q, m := new(big.Int).QuoRem(a, b, new(big.Int))
+	if m.Sign() != 0 && m.Sign() != b.Sign() {
+		q.Sub(q, big.NewInt(1))
+		m.Add(m, b)
+	}
+	return q, m
+}
+
+func floatOperation(op string, a *big.Float, b *big.Float) (Number, error) {
+	switch op {
+	case token.PLUS:
+		return floatNumber(new(big.Float).Add(a, b)), nil
+	case token.MINUS:
+		return floatNumber(new(big.Float).Sub(a, b)), nil
+	case token.MUL:
+		return floatNumber(new(big.Float).Mul(a, b)), nil
+	case token.DIV:
+		if b.Sign() == 0 {
+			return Number{}, newError(DivByZero, op)
+		}
+		return floatNumber(new(big.Float).Quo(a, b)), nil
+	case token.FLOOR_DIV, token.MOD:
+		if b.Sign() == 0 {
+			return Number{}, newError(DivByZero, op)
+		}
+		q := floorFloat(new(big.Float).Quo(a, b))
+		if op == token.FLOOR_DIV {
+			return floatNumber(q), nil
+		}
+		// a % b == a - b * floor(a / b)
+		return floatNumber(new(big.Float).Sub(a, new(big.Float).Mul(b, q))), nil
+	case token.POW:
+		return floatPow(a, b)
+	}
 
-			return Number{Value: &IntNumber{Value: *val}, IsInt: true}, false, true
-
-		}
-
-		fb = &x.Value.(*FloatNumber).Value
-		fa = new(big.Float).SetInt(&ia)
-		switch op {
-		case token.PLUS:
-			val = new(big.Float).Add(fa, fb)
-		case token.MINUS:
-			val = new(big.Float).Sub(fa, fb)
-		case token.MUL:
-			val = new(big.Float).Mul(fa, fb)
-		case token.DIV:
-			val = new(big.Float).Quo(fa, fb)
-		case token.GT:
-			return Number{}, FloatFloatCompare(token.GT, *fa, *fb), true
-		case token.GTE:
-			return Number{}, FloatFloatCompare(token.GTE, *fa, *fb), true
-		case token.LT:
-			return Number{}, FloatFloatCompare(token.LT, *fa, *fb), true
-		case token.LTE:
-			return Number{}, FloatFloatCompare(token.LTE, *fa, *fb), true
-		case token.NOT_EQ:
-			return Number{}, FloatFloatCompare(token.NOT_EQ, *fa, *fb), true
-		case token.EQEQ:
-			return Number{}, FloatFloatCompare(token.EQEQ, *fa, *fb), true
-
-		}
-		return Number{Value: &FloatNumber{Value: *val}, IsInt: false}, false, true
-	default:
-		return Number{}, false, false
+	return Number{}, newError(UnknownOperator, op)
+}
+
+// floorFloat rounds `f` towards negative infinity
+func floorFloat(f *big.Float) *big.Float {
+	i, acc := f.Int(nil)
+	if f.Sign() < 0 && acc != big.Exact {
+		i.Sub(i, big.NewInt(1))
+	}
+	return new(big.Float).SetPrec(f.Prec()).SetInt(i)
+}
+
+func floatPow(a *big.Float, b *big.Float) (Number, error) {
+	if b.IsInt() {
+		if exp, acc := b.Int64(); acc == big.Exact {
+			neg := exp < 0
+			if neg {
+				exp = -exp
+			}
+
+			// exponentiation by squaring
+			res := new(big.Float).SetPrec(a.Prec()).SetInt64(1)
+			base := new(big.Float).Copy(a)
+			for ; exp > 0; exp >>= 1 {
+				if exp&1 == 1 {
+					res.Mul(res, base)
+				}
+				base.Mul(base, base)
+			}
+
+			if neg {
+				if res.Sign() == 0 {
+					return Number{}, newError(DivByZero, token.POW)
+				}
+				res.Quo(new(big.Float).SetPrec(res.Prec()).SetInt64(1), res)
+			}
+			return floatNumber(res), nil
+		}
+	}
+
+	if a.Sign() < 0 && !b.IsInt() {
+		return Number{}, newError(NotANumber, token.POW)
+	}
+	if a.Sign() == 0 {
+		if b.Sign() < 0 {
+			return Number{}, newError(DivByZero, token.POW)
+		}
+		return floatNumber(new(big.Float).SetPrec(a.Prec())), nil
 	}
+	return floatNumber(bigPow(a, b)), nil
 }
@@ -1,5 +1,7 @@
 package object
 
+import "sort"
+
 type Env struct {
 	str   map[string]Obj
 	outer *Env
@@ -25,6 +27,45 @@ func (e *Env) Set(n string, v Obj) Obj {
 	return v
 }
 
+// Assign updates the existing binding of `n` in the
+// innermost scope having one. Returns false if `n`
+// was never declared.
+func (e *Env) Assign(n string, v Obj) (Obj, bool) {
+	if _, ok := e.str[n]; ok {
+		e.str[n] = v
+		return v, true
+	}
+
+	if e.outer != nil {
+		return e.outer.Assign(n, v)
+	}
+
+	return nil, false
+}
+
+// Own returns the value of `n` in this scope, without looking in
+// the scopes around it
+func (e *Env) Own(n string) (Obj, bool) {
+	val, ok := e.str[n]
+	return val, ok
+}
+
+// Names returns the names bound in this scope, sorted, without
+// those of the scopes around it
+func (e *Env) Names() []string {
+	names := make([]string, 0, len(e.str))
+	for n := range e.str {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Outer returns the scope around this one; nil for the top level
+func (e *Env) Outer() *Env {
+	return e.outer
+}
+
 func NewEnclosedEnv(outer *Env) *Env {
 	env := NewEnv()
 	env.outer = outer
@@ -3,5 +3,5 @@ package object
 import "vabna/number"
 
 func MakeIntNumber(i int64) Obj {
-    return &Number{ Value : number.MakeInt(i)}
+    return &Number{ Value : number.MakeInt(i) , IsInt: true }
 }
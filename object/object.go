@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"hash/fnv"
 	"strings"
+	"unicode"
 	"vabna/ast"
+	"vabna/errs"
 	"vabna/number"
-
+	"vabna/token"
 )
 
 const (
@@ -22,6 +24,10 @@ const (
 	BUILTIN_OBJ    = "BUILTIN"
 	ARRAY_OBJ      = "ARRAY"
 	HASH_OBJ       = "HASH"
+	RANGE_OBJ      = "RANGE"
+	BREAK_OBJ      = "BREAK"
+	CONTINUE_OBJ   = "CONTINUE"
+	MODULE_OBJ     = "MODULE"
     NUM_OBJ        = "NUM"
 )
 
@@ -116,6 +122,16 @@ type HashPair struct {
 
 type Hash struct {
 	Pairs map[HashKey]HashPair
+	// Order holds the keys of Pairs in insertion order
+	Order []HashKey
+}
+
+// Set inserts or replaces a pair, remembering the insertion order
+func (h *Hash) Set(key HashKey, pair HashPair) {
+	if _, ok := h.Pairs[key]; !ok {
+		h.Order = append(h.Order, key)
+	}
+	h.Pairs[key] = pair
 }
 
 func (h *Hash) Type() ObjType { return HASH_OBJ }
@@ -125,7 +141,8 @@ func (h *Hash) Inspect() string {
 
 	pairs := []string{}
 
-	for _, p := range h.Pairs {
+	for _, k := range h.Order {
+		p := h.Pairs[k]
 		pairs = append(pairs, fmt.Sprintf("%s : %s", p.Key.Inspect(), p.Value.Inspect()))
 
 	}
@@ -141,6 +158,51 @@ type Hashable interface {
 	HashKey() HashKey
 }
 
+// Range of integers -> range(0, 10, 2)
+
+type Range struct {
+	Start int64
+	Stop  int64
+	Step  int64
+}
+
+func (r *Range) Type() ObjType { return RANGE_OBJ }
+func (r *Range) Inspect() string {
+	return number.Localize(fmt.Sprintf("range(%d, %d, %d)", r.Start, r.Stop, r.Step))
+}
+
+// Len is the number of integers in the range. It is counted in
+// uint64, where the distance between any two int64 values fits,
+// so ranges like range(MinInt64, MaxInt64) do not overflow.
+func (r *Range) Len() uint64 {
+	if r.Step > 0 && r.Start < r.Stop {
+		return (uint64(r.Stop)-uint64(r.Start)-1)/uint64(r.Step) + 1
+	}
+	if r.Step < 0 && r.Start > r.Stop {
+		// -r.Step wraps for MinInt64, but is right as a uint64
+		return (uint64(r.Start)-uint64(r.Stop)-1)/uint64(-r.Step) + 1
+	}
+	return 0
+}
+
+// At returns the `i`th integer of the range; i must be below Len
+func (r *Range) At(i uint64) int64 {
+	// the product may wrap, but the sum is in the range and
+	// so comes out right in two's complement
+	return r.Start + int64(i)*r.Step
+}
+
+// Module is an imported `.vab` file; its top level
+// bindings are reached with `module.name`
+type Module struct {
+	Name string
+	Path string
+	Env  *Env
+}
+
+func (m *Module) Type() ObjType   { return MODULE_OBJ }
+func (m *Module) Inspect() string { return "module " + m.Name + " (" + m.Path + ")" }
+
 //Strings "I am a string"
 type String struct {
 	Value string
@@ -161,7 +223,7 @@ func (num *Number) Type() ObjType{
 }
 
 func (num *Number) Inspect() string{
-    return fmt.Sprintf("%s" , num.Value.Value.String())
+    return num.Value.String()
 }
 
 
@@ -187,14 +249,127 @@ type ReturnValue struct {
 func (r *ReturnValue) Type() ObjType   { return RETURN_VAL_OBJ }
 func (r *ReturnValue) Inspect() string { return r.Value.Inspect() }
 
+// Break and Continue are passed up from `thamo` and
+// `chalao` statements to the enclosing loop
+
+type Break struct{}
+
+func (b *Break) Type() ObjType   { return BREAK_OBJ }
+func (b *Break) Inspect() string { return "break" }
+
+type Continue struct{}
+
+func (c *Continue) Type() ObjType   { return CONTINUE_OBJ }
+func (c *Continue) Inspect() string { return "continue" }
+
 type Error struct {
-	Msg string
+	// Code is the code of the message in the error catalogs
+	Code string
+	Msg  string
+	// Pos and End mark the source code which caused the error
+	Pos token.Pos
+	End token.Pos
+	// Trace is the stack of function calls active when
+	// the error happened, outermost call first
+	Trace []Frame
+}
+
+// Frame is a single call of a user defined function
+type Frame struct {
+	// Name of the called function; empty for anonymous functions
+	Name string
+	// Pos is the position of the call expression
+	Pos token.Pos
 }
 
-func (e *Error) Type() ObjType   { return ERR_OBJ }
-func (e *Error) Inspect() string { return "ERR : " + e.Msg }
+func (e *Error) Type() ObjType { return ERR_OBJ }
+func (e *Error) Inspect() string {
+	head := errs.Msg(errs.ERROR)
+	if e.Code != "" {
+		head += " [" + e.Code + "]"
+	}
+
+	if !e.Pos.IsValid() {
+		return head + " : " + e.Msg
+	}
+
+	var out bytes.Buffer
+	out.WriteString(head + " : " + e.Pos.String() + ": " + e.Msg)
+	if excerpt := e.Excerpt(); excerpt != "" {
+		out.WriteString("\n")
+		out.WriteString(excerpt)
+	}
+	if len(e.Trace) > 0 {
+		out.WriteString("\n")
+		out.WriteString(e.StackTrace())
+	}
+	return out.String()
+}
+
+// StackTrace lists the function calls which led to the error,
+// most recent call last
+func (e *Error) StackTrace() string {
+	var out bytes.Buffer
+	out.WriteString(errs.Msg(errs.TRACEBACK))
+	for _, f := range e.Trace {
+		name := f.Name
+		if name == "" {
+			name = errs.Msg(errs.ANONYMOUS_FN)
+		}
+		out.WriteString(fmt.Sprintf("\n  %s: %s", f.Pos.String(), name))
+	}
+	return out.String()
+}
+
+// Excerpt returns the offending source line with the
+// erroneous part underlined with carets, like
+//
+//	3 | দেখাও(বয়স + "বছর");
+//	  |       ^^^^^^^^^^^^
+func (e *Error) Excerpt() string {
+	line, ok := e.Pos.File.Line(e.Pos.Line)
+	if !ok {
+		return ""
+	}
+	src := []rune(line)
+
+	start := e.Pos.Column - 1
+	if start > len(src) {
+		start = len(src)
+	}
+	end := len(src)
+	if e.End.Line == e.Pos.Line && e.End.Column > e.Pos.Column && e.End.Column-1 < end {
+		end = e.End.Column - 1
+	}
+
+	var pad, carets strings.Builder
+	for i, r := range src[:end] {
+		// combining marks take no space on screen
+		if unicode.In(r, unicode.Mn, unicode.Me) {
+			continue
+		}
+		if i < start {
+			if r == '\t' {
+				pad.WriteRune('\t')
+			} else {
+				pad.WriteRune(' ')
+			}
+		} else {
+			carets.WriteRune('^')
+		}
+	}
+	if carets.Len() == 0 {
+		carets.WriteRune('^')
+	}
+
+	lineNo := fmt.Sprintf("%d", e.Pos.Line)
+	gutter := strings.Repeat(" ", len(lineNo))
+	return fmt.Sprintf("  %s | %s\n  %s | %s%s", lineNo, line, gutter, pad.String(), carets.String())
+}
 
 type Function struct {
+	// Name is the name the function was first bound to with `ধরি`
+	Name   string
 	Params []*ast.Identifier
 	Body   *ast.BlockStmt
 	Env    *Env
@@ -1,31 +1,42 @@
 package parser
 
 import (
-	"fmt"
+	"strings"
 	"math/big"
 	"vabna/ast"
 	"vabna/errs"
 	"vabna/lexer"
 	"vabna/number"
 	"vabna/token"
-
-	log "github.com/sirupsen/logrus"
+	"vabna/trace"
 )
 
 const (
 	_ int = iota
 	LOWEST
+	ASSIGN
+	LOGIC_OR
+	LOGIC_AND
 	EQUALS
 	LTGT
 	SUM
 	PROD
 	PREFIX
+	POWER
 	CALL
 	INDEX
 )
 
 var precedences = map[token.TokenType]int{
 
+	token.EQ:       ASSIGN,
+	token.PLUS_EQ:  ASSIGN,
+	token.MINUS_EQ: ASSIGN,
+	token.MUL_EQ:   ASSIGN,
+	token.DIV_EQ:   ASSIGN,
+
+	token.OR:         LOGIC_OR,
+	token.AND:        LOGIC_AND,
 	token.EQEQ:       EQUALS,
 	token.NOT_EQ:     EQUALS,
 	token.LT:         LTGT,
@@ -36,8 +47,21 @@ var precedences = map[token.TokenType]int{
 	token.MINUS:      SUM,
 	token.DIV:        PROD,
 	token.MUL:        PROD,
+	token.MOD:        PROD,
+	token.FLOOR_DIV:  PROD,
+	token.POW:        POWER,
 	token.LPAREN:     CALL,
 	token.LS_BRACKET: INDEX,
+	token.DOT:        INDEX,
+}
+
+// Precedence returns how tightly the infix operator `t` binds;
+// LOWEST for tokens which are not operators
+func Precedence(t token.TokenType) int {
+	if p, ok := precedences[t]; ok {
+		return p
+	}
+	return LOWEST
 }
 
 type Parser struct {
@@ -46,6 +70,19 @@ type Parser struct {
 	peekTok token.Token
 
 	errs []errs.ParserError
+	// recovering is set after an error until the parser finds
+	// the start of the next statement; errors in between are
+	// caused by the first one and are not reported
+	recovering bool
+
+	// braces and groups are the number of `{` and of `(`, `[`
+	// open at the current token
+	braces int
+	groups int
+
+	// loopDepth is the number of loops around the current token
+	// in the function being parsed; `thamo` and `chalao` need one
+	loopDepth int
 
 	prefixParseFns map[token.TokenType]prefixParseFn
 	infixParseFns  map[token.TokenType]infixParseFn
@@ -75,8 +112,11 @@ func NewParser(l *lexer.Lexer) *Parser {
 	p.regPrefix(token.LPAREN, p.parseGroupedExpr)
 	p.regPrefix(token.IF, p.parseIfExpr)
     p.regPrefix(token.WHILE , p.parseWhileExpr)
+	p.regPrefix(token.FOREACH, p.parseForEachExpr)
 	p.regPrefix(token.EKTI, p.parseFunc)
+	p.regPrefix(token.FUNC, p.parseBareFunc)
 	p.regPrefix(token.STRING, p.parseStringLit)
+	p.regPrefix(token.ISTRING, p.parseInterpStringLit)
 	p.regPrefix(token.LS_BRACKET, p.parseArrLit)
 	p.regPrefix(token.LBRACE, p.parseHashLit)
 
@@ -86,14 +126,25 @@ func NewParser(l *lexer.Lexer) *Parser {
 	p.regInfix(token.MINUS, p.parseInfixExpr)
 	p.regInfix(token.DIV, p.parseInfixExpr)
 	p.regInfix(token.MUL, p.parseInfixExpr)
+	p.regInfix(token.MOD, p.parseInfixExpr)
+	p.regInfix(token.FLOOR_DIV, p.parseInfixExpr)
+	p.regInfix(token.POW, p.parseInfixExpr)
 	p.regInfix(token.EQEQ, p.parseInfixExpr)
 	p.regInfix(token.NOT_EQ, p.parseInfixExpr)
 	p.regInfix(token.LT, p.parseInfixExpr)
 	p.regInfix(token.GTE, p.parseInfixExpr)
 	p.regInfix(token.GT, p.parseInfixExpr)
 	p.regInfix(token.LTE, p.parseInfixExpr)
+	p.regInfix(token.AND, p.parseInfixExpr)
+	p.regInfix(token.OR, p.parseInfixExpr)
+	p.regInfix(token.EQ, p.parseAssignExpr)
+	p.regInfix(token.PLUS_EQ, p.parseAssignExpr)
+	p.regInfix(token.MINUS_EQ, p.parseAssignExpr)
+	p.regInfix(token.MUL_EQ, p.parseAssignExpr)
+	p.regInfix(token.DIV_EQ, p.parseAssignExpr)
 	p.regInfix(token.LPAREN, p.parseCallExpr)
 	p.regInfix(token.LS_BRACKET, p.parseIndexExpr)
+	p.regInfix(token.DOT, p.parseMemberExpr)
 
 	p.nextToken()
 	p.nextToken()
@@ -120,6 +171,7 @@ func (p *Parser) parseHashLit() ast.Expr {
 		val := p.parseExpr(LOWEST)
 
 		hash.Pairs[k] = val
+		hash.Keys = append(hash.Keys, k)
 
 		if !p.isPeekToken(token.RBRACE) && !p.peek(token.COMMA) {
 			return nil
@@ -129,6 +181,7 @@ func (p *Parser) parseHashLit() ast.Expr {
 	if !p.peek(token.RBRACE) {
 		return nil
 	}
+	hash.Close = p.curTok
 	return hash
 
 }
@@ -143,6 +196,19 @@ func (p *Parser) parseIndexExpr(l ast.Expr) ast.Expr {
 	if !p.peek(token.RS_BRACKET) {
 		return nil
 	}
+	e.Close = p.curTok
+
+	return e
+}
+
+func (p *Parser) parseMemberExpr(l ast.Expr) ast.Expr {
+	e := &ast.MemberExpr{Token: p.curTok, Left: l}
+
+	if !p.peek(token.IDENT) {
+		return nil
+	}
+
+	e.Name = &ast.Identifier{Token: p.curTok, Value: p.curTok.Literal}
 
 	return e
 }
@@ -151,6 +217,7 @@ func (p *Parser) parseArrLit() ast.Expr {
 	arr := &ast.ArrLit{Token: p.curTok}
 
 	arr.Elms = p.parseExprList(token.RS_BRACKET)
+	arr.Close = p.curTok
 
 	return arr
 }
@@ -185,6 +252,47 @@ func (p *Parser) parseStringLit() ast.Expr {
 	return &ast.StringLit{Token: p.curTok, Value: p.curTok.Literal}
 }
 
+func (p *Parser) parseInterpStringLit() ast.Expr {
+	lit := &ast.InterpStringLit{Token: p.curTok}
+
+	for _, part := range lexer.SplitString(p.curTok) {
+		if !part.IsExpr {
+			tk := token.Token{Type: token.STRING, Literal: part.Text, LineNo: part.Pos.Line, Column: part.Pos.Column, Offset: part.Pos.Offset, File: part.Pos.File, End: part.End}
+			lit.Parts = append(lit.Parts, &ast.StringLit{Token: tk, Value: part.Text})
+			continue
+		}
+
+		expr := p.parseEmbeddedExpr(part)
+		if expr == nil {
+			return nil
+		}
+		lit.Parts = append(lit.Parts, expr)
+	}
+
+	return lit
+}
+
+// parseEmbeddedExpr parses the expression inside `{}` of an
+// interpolated string with a parser of its own; errors are
+// reported at their place in the file
+func (p *Parser) parseEmbeddedExpr(part lexer.StringPart) ast.Expr {
+	lx := lexer.NewLexerAt(part.Text, part.Pos)
+	sub := NewParser(&lx)
+
+	expr := sub.parseExpr(LOWEST)
+	if len(sub.errs) == 0 && !sub.isPeekToken(token.EOF) {
+		sub.peekErr(token.RBRACE)
+	}
+
+	if len(sub.errs) != 0 {
+		for _, e := range sub.errs {
+			p.addErr(e)
+		}
+		return nil
+	}
+	return expr
+}
+
 func (p *Parser) parseFunc() ast.Expr {
 
 	if !p.peek(token.FUNC) {
@@ -193,21 +301,39 @@ func (p *Parser) parseFunc() ast.Expr {
 
 	}
 
+	return p.parseFuncLit()
+}
+
+// parseBareFunc parses a function written without EKTI, which only
+// the spellings of FUNC in token.BareFuncs can be
+func (p *Parser) parseBareFunc() ast.Expr {
+	if !token.BareFuncs[p.curTok.Literal] {
+		p.noPrefixFunctionErr(p.curTok.Type)
+		return nil
+	}
+	return p.parseFuncLit()
+}
+
+// parseFuncLit parses a function from its FUNC token on
+func (p *Parser) parseFuncLit() ast.Expr {
 	fl := &ast.FunctionLit{Token: p.curTok}
 	//fmt.Println(fl.Token)
 	if !p.peek(token.LPAREN) {
 		return nil
 	}
 
+	fl.Open = p.curTok
 	fl.Params = p.parseFuncParams()
+	fl.Close = p.curTok
 
 	if !p.peek(token.LBRACE) {
 		return nil
 	}
 
+	outerLoopDepth := p.loopDepth
+	p.loopDepth = 0
 	fl.Body = p.parseBlockStmt()
-
-	log.Info("FN EXPR => ", fl.Body.String())
+	p.loopDepth = outerLoopDepth
 
 	return fl
 }
@@ -236,13 +362,13 @@ func (p *Parser) parseFuncParams() []*ast.Identifier {
 		return nil
 	}
 
-	log.Info("FUNC PARAMS => ", ids)
 	return ids
 }
 
 func (p *Parser) parseCallExpr(function ast.Expr) ast.Expr {
 	exp := &ast.CallExpr{Token: p.curTok, Func: function}
 	exp.Args = p.parseExprList(token.RPAREN)
+	exp.Close = p.curTok
 	return exp
 }
 
@@ -291,30 +417,130 @@ func (p *Parser) peekErr(t token.TokenType) {
 	}
 	//msg := fmt.Sprintf(errs.Errs["EXPECTED_GOT"], expectedToken, p.peekTok.Literal)
 	newerr := errs.PeekError{Expected: expectedToken, Got: p.peekTok}
-	p.errs = append(p.errs, &newerr)
+	p.addErr(&newerr)
 	//p.errs = append(p.errs, errs.NewParserError(errs.EXPECTED_GOT, p.peekTok))
 }
 
+// addErr reports `e` unless the parser is recovering from an earlier error
+func (p *Parser) addErr(e errs.ParserError) {
+	if p.recovering {
+		return
+	}
+	p.errs = append(p.errs, e)
+	p.recovering = true
+}
+
 func (p *Parser) nextToken() {
 	p.curTok = p.peekTok
 	p.peekTok = p.lx.NextToken()
+
+	switch p.curTok.Type {
+	case token.LBRACE:
+		p.braces += 1
+	case token.RBRACE:
+		if p.braces > 0 {
+			p.braces -= 1
+		}
+	case token.LPAREN, token.LS_BRACKET:
+		p.groups += 1
+	case token.RPAREN, token.RS_BRACKET:
+		if p.groups > 0 {
+			p.groups -= 1
+		}
+	}
 }
 
-func (p *Parser) ParseProg() *ast.Program {
-	prog := &ast.Program{}
-	prog.Stmts = []ast.Stmt{}
+// level is how deeply nested the statements of a block are
+type level struct {
+	braces int
+	groups int
+}
+
+func (p *Parser) level() level {
+	return level{braces: p.braces, groups: p.groups}
+}
+
+// stmtKeywords can only be the first token of a statement
+var stmtKeywords = map[token.TokenType]bool{
+	token.LET:      true,
+	token.RETURN:   true,
+	token.IMPORT:   true,
+	token.BREAK:    true,
+	token.CONTINUE: true,
+}
+
+// synchronize skips the rest of a broken statement of a block at `lv`.
+// It stops at the last token of the statement: a `;`, the token before
+// the `}` closing the block, before a keyword starting a statement or
+// at the end of a line, once all brackets opened in the statement are
+// closed. If the statement ran into the `}` closing the block, it
+// stops there.
+func (p *Parser) synchronize(lv level) {
+	defer func() {
+		// brackets left open by the statement are abandoned with it
+		p.groups = lv.groups
+		p.recovering = false
+	}()
+
+	for !p.isCurToken(token.EOF) {
+		if p.braces < lv.braces {
+			return
+		}
+
+		if p.braces == lv.braces {
+			if p.isCurToken(token.SEMICOLON) || p.isPeekToken(token.RBRACE) || p.isPeekToken(token.EOF) {
+				return
+			}
+			if stmtKeywords[p.peekTok.Type] {
+				return
+			}
+			if p.groups <= lv.groups && p.peekTok.LineNo > p.curTok.LineNo {
+				return
+			}
+		}
+
+		p.nextToken()
+	}
+}
+
+// parseStmts parses statements until the `}` closing a block at `lv`
+// or the end of the file. Broken statements are left out, and parsing
+// goes on from the next statement.
+func (p *Parser) parseStmts(lv level) []ast.Stmt {
+	stmts := []ast.Stmt{}
+
+	for !p.isCurToken(token.EOF) {
+		if lv.braces > 0 && p.isCurToken(token.RBRACE) {
+			break
+		}
 
-	for p.curTok.Type != token.EOF {
-		//fmt.Println(p.curTok)
 		stmt := p.parseStmt()
 
+		if p.recovering {
+			p.synchronize(lv)
+			stmt = nil
+		}
+
 		if stmt != nil {
-			prog.Stmts = append(prog.Stmts, stmt)
+			trace.Node(stmt)
+			stmts = append(stmts, stmt)
+		}
+
+		if p.braces < lv.braces {
+			// the broken statement ate the closing `}`
+			break
 		}
 
 		p.nextToken()
 	}
 
+	return stmts
+}
+
+func (p *Parser) ParseProg() *ast.Program {
+	prog := &ast.Program{}
+	prog.Stmts = p.parseStmts(p.level())
+
 	return prog
 }
 
@@ -322,9 +548,16 @@ func (p *Parser) parseStmt() ast.Stmt {
 	//fmt.Println(p.curTok.Type , p.peekTok)
 	switch p.curTok.Type {
 	case token.LET:
-		return p.parseLetStmt()
+		if stmt := p.parseLetStmt(); stmt != nil {
+			return stmt
+		}
+		return nil
 	case token.RETURN:
 		return p.parseReturnStmt()
+	case token.BREAK, token.CONTINUE:
+		return p.parseLoopControlStmt()
+	case token.IMPORT:
+		return p.parseImportStmt()
 	default:
 		return p.parseExprStmt()
 
@@ -342,7 +575,40 @@ func (p *Parser) parseReturnStmt() *ast.ReturnStmt {
 		p.nextToken()
 	}
 
-	log.Info(fmt.Sprintf("RETURN STMT => %v\n", stmt))
+	return stmt
+}
+
+func (p *Parser) parseLoopControlStmt() ast.Stmt {
+	var stmt ast.Stmt
+	if p.isCurToken(token.BREAK) {
+		stmt = &ast.BreakStmt{Token: p.curTok}
+	} else {
+		stmt = &ast.ContinueStmt{Token: p.curTok}
+	}
+
+	if p.loopDepth == 0 {
+		p.errs = append(p.errs, &errs.OutsideLoopError{Token: p.curTok})
+	}
+
+	if p.isPeekToken(token.SEMICOLON) {
+		p.nextToken()
+	}
+
+	return stmt
+}
+
+func (p *Parser) parseImportStmt() ast.Stmt {
+	// ano "path/to/module.vab";
+	stmt := &ast.ImportStmt{Token: p.curTok}
+
+	if !p.peek(token.STRING) {
+		return nil
+	}
+	stmt.Path = &ast.StringLit{Token: p.curTok, Value: p.curTok.Literal}
+
+	if p.isPeekToken(token.SEMICOLON) {
+		p.nextToken()
+	}
 
 	return stmt
 }
@@ -350,6 +616,7 @@ func (p *Parser) parseReturnStmt() *ast.ReturnStmt {
 func (p *Parser) parseLetStmt() *ast.LetStmt {
 	//LET <IDENTIFIER> <EQUAL_SIGN> <EXPRESSION>
 	stmt := &ast.LetStmt{Token: p.curTok}
+	stmt.Doc = p.docComment(p.curTok)
 
 	if !p.peek(token.IDENT) {
 		return nil
@@ -366,11 +633,45 @@ func (p *Parser) parseLetStmt() *ast.LetStmt {
 		p.nextToken()
 	}
 
-	log.Info(fmt.Sprintf("LET STMT => %v\n", stmt))
 	return stmt
 
 }
 
+// docComment returns the text of the comments written on the
+// lines right above `tok`, each on a line of its own
+func (p *Parser) docComment(tok token.Token) string {
+	comments := p.lx.Comments()
+	doc := []string{}
+	line := tok.LineNo
+
+	for i := len(comments) - 1; i >= 0; i-- {
+		c := comments[i]
+		if c.Pos.Offset >= tok.Offset {
+			continue
+		}
+		if c.EndLine() != line-1 || !startsLine(c.Pos) {
+			break
+		}
+		doc = append([]string{c.Content()}, doc...)
+		line = c.Pos.Line
+	}
+
+	return strings.Join(doc, "\n")
+}
+
+// startsLine reports whether only whitespace comes before `pos` on its line
+func startsLine(pos token.Pos) bool {
+	src, ok := pos.File.Line(pos.Line)
+	if !ok {
+		return false
+	}
+	before := []rune(src)
+	if pos.Column-1 < len(before) {
+		before = before[:pos.Column-1]
+	}
+	return strings.TrimSpace(string(before)) == ""
+}
+
 func (p *Parser) parseExprStmt() *ast.ExprStmt {
 	//fmt.Println(p.curTok)
 	stmt := &ast.ExprStmt{Token: p.curTok}
@@ -386,12 +687,14 @@ func (p *Parser) parseExprStmt() *ast.ExprStmt {
 
 func (p *Parser) noPrefixFunctionErr(t token.TokenType) {
 	var msg errs.ParserError
-	if t == token.FUNC {
-		msg = &errs.NoEktiError{Type: t}
+	if t == token.ILLEGAL {
+		msg = &errs.IllegalTokenError{Token: p.curTok}
+	} else if t == token.FUNC {
+		msg = &errs.NoEktiError{Type: t, Token: p.curTok}
 	} else {
-		msg = &errs.NoPrefixSuffixError{Type: t}
+		msg = &errs.NoPrefixSuffixError{Type: t, Token: p.curTok}
 	}
-	p.errs = append(p.errs, msg)
+	p.addErr(msg)
 }
 
 func (p *Parser) parseGroupedExpr() ast.Expr {
@@ -414,6 +717,7 @@ func (p *Parser) parseExpr(prec int) ast.Expr {
 	}
 
 	leftExpr := prefix()
+	p.traceNode(leftExpr)
 
 	for !p.isPeekToken(token.SEMICOLON) && prec < p.peekPrec() {
 		infix := p.infixParseFns[p.peekTok.Type]
@@ -425,6 +729,7 @@ func (p *Parser) parseExpr(prec int) ast.Expr {
 		p.nextToken()
 
 		leftExpr = infix(leftExpr)
+		p.traceNode(leftExpr)
 	}
 
 	//fmt.Println(leftExpr)
@@ -432,8 +737,15 @@ func (p *Parser) parseExpr(prec int) ast.Expr {
 
 }
 
+// traceNode traces `node` once it has parsed; a node broken by an
+// error may miss parts, so it is left out
+func (p *Parser) traceNode(node ast.Node) {
+	if !p.recovering {
+		trace.Node(node)
+	}
+}
+
 func (p *Parser) parseIdent() ast.Expr {
-	log.Info("IDENT EXPR =>", p.curTok)
 	return &ast.Identifier{
 		Token: p.curTok,
 		Value: p.curTok.Literal,
@@ -442,7 +754,6 @@ func (p *Parser) parseIdent() ast.Expr {
 }
 
 func (p *Parser) parseBool() ast.Expr {
-	log.Info("BOOL EXPR => ", p.curTok)
 	return &ast.Boolean{Token: p.curTok, Value: p.isCurToken(token.TRUE)}
 }
 
@@ -482,7 +793,6 @@ func (p *Parser) parsePrefixExpr() ast.Expr {
 	p.nextToken()
 	exp.Right = p.parseExpr(PREFIX)
 
-	log.Info("PREFIX => ", exp.Token, exp.Right)
 	return exp
 }
 
@@ -494,18 +804,46 @@ func (p *Parser) parseInfixExpr(left ast.Expr) ast.Expr {
 		Left:  left,
 	}
 
+	if p.isCurToken(token.AND) || p.isCurToken(token.OR) {
+		// `এবং` -> `&&`, `অথবা` -> `||`
+		exp.Op = string(p.curTok.Type)
+	}
+
 	prec := p.curPrec()
+	if p.isCurToken(token.POW) {
+		// right associative; 2 ** 3 ** 2 == 2 ** (3 ** 2)
+		prec -= 1
+	}
 	p.nextToken()
 	exp.Right = p.parseExpr(prec)
 
-	log.Info("INFIX => ", exp.Left, exp.Op, exp.Right)
+	return exp
+}
+
+func (p *Parser) parseAssignExpr(target ast.Expr) ast.Expr {
+	exp := &ast.AssignExpr{Token: p.curTok, Target: target}
+
+	switch target.(type) {
+	case *ast.Identifier, *ast.IndexExpr:
+	default:
+		p.addErr(&errs.AssignTargetError{Token: p.curTok})
+		return nil
+	}
+
+	if p.curTok.Type != token.EQ {
+		// `+=` -> `+`
+		exp.Op = string(p.curTok.Type[:len(p.curTok.Type)-1])
+	}
+
+	p.nextToken()
+	// assignments are right associative; `a = b = 1`
+	exp.Value = p.parseExpr(ASSIGN - 1)
 
 	return exp
 }
 
 func (p *Parser) parseIfExpr() ast.Expr {
 	exp := &ast.IfExpr{Token: p.curTok}
-	has_else := false
 	if !p.peek(token.LPAREN) {
 		return nil
 	}
@@ -533,16 +871,9 @@ func (p *Parser) parseIfExpr() ast.Expr {
 		if !p.peek(token.LBRACE) {
 			return nil
 		}
-		has_else = true
 		exp.ElseBlock = p.parseBlockStmt()
 	}
 
-	if has_else {
-		log.Info("IF ELSE Expr => ", exp.Cond, exp.TrueBlock.String(), exp.ElseBlock.String())
-	} else {
-		log.Info("IF Expr => ", exp.Cond, exp.TrueBlock.String())
-	}
-
 	return exp
 }
 
@@ -559,25 +890,72 @@ func (p *Parser) parseWhileExpr() ast.Expr{
 
     if !p.peek(token.LBRACE){ return nil }
 
-    exp.StmtBlock = p.parseBlockStmt()
+    exp.StmtBlock = p.parseLoopBody()
 
     return exp
 
 }
 
+func (p *Parser) parseForEachExpr() ast.Expr {
+	// protiti (x moddhe arr) { ... }
+	// protiti (k, v moddhe hash) { ... }
+	exp := &ast.ForEachExpr{Token: p.curTok}
+
+	if !p.peek(token.LPAREN) {
+		return nil
+	}
+
+	if !p.peek(token.IDENT) {
+		return nil
+	}
+	exp.Vars = append(exp.Vars, &ast.Identifier{Token: p.curTok, Value: p.curTok.Literal})
+
+	if p.isPeekToken(token.COMMA) {
+		p.nextToken()
+		if !p.peek(token.IDENT) {
+			return nil
+		}
+		exp.Vars = append(exp.Vars, &ast.Identifier{Token: p.curTok, Value: p.curTok.Literal})
+	}
+
+	if !p.peek(token.IN) {
+		return nil
+	}
+
+	p.nextToken()
+	exp.Iter = p.parseExpr(LOWEST)
+
+	if !p.peek(token.RPAREN) {
+		return nil
+	}
+
+	if !p.peek(token.LBRACE) {
+		return nil
+	}
+
+	exp.StmtBlock = p.parseLoopBody()
+
+	return exp
+}
+
+func (p *Parser) parseLoopBody() *ast.BlockStmt {
+	p.loopDepth += 1
+	defer func() { p.loopDepth -= 1 }()
+
+	return p.parseBlockStmt()
+}
+
 func (p *Parser) parseBlockStmt() *ast.BlockStmt {
 	bs := &ast.BlockStmt{Token: p.curTok}
 
-	bs.Stmts = []ast.Stmt{}
-
+	lv := p.level()
 	p.nextToken()
 
-	for !p.isCurToken(token.RBRACE) && !p.isCurToken(token.EOF) {
-		s := p.parseStmt()
-		if s != nil {
-			bs.Stmts = append(bs.Stmts, s)
-		}
-		p.nextToken()
+	bs.Stmts = p.parseStmts(lv)
+	bs.Close = p.curTok
+
+	if p.isCurToken(token.EOF) {
+		p.addErr(&errs.PeekError{Expected: token.RBRACE, Got: p.curTok})
 	}
 	//fmt.Println("BS=> " , bs)
 
@@ -0,0 +1,156 @@
+package parser
+
+import (
+	"testing"
+	"vabna/ast"
+	"vabna/errs"
+	"vabna/lexer"
+)
+
+func parseErrors(input string) []errs.ParserError {
+	l := lexer.NewLexer(input)
+	p := NewParser(&l)
+	p.ParseProg()
+	return p.GetErrors()
+}
+
+func TestLoopControlOutsideLoop(t *testing.T) {
+	tests := []struct {
+		input     string
+		errsCount int
+	}{
+		{"থামো;", 1},
+		{"চালাও;", 1},
+		{"jotokhon (সত্য) { থামো; }", 0},
+		{"প্রতিটি (ক মধ্যে [1]) { যদি (ক) তাহলে { চালাও; } }", 0},
+		{"jotokhon (সত্য) { ধরি ক = একটি কাজ() { থামো; }; }", 1},
+		{"ধরি ক = একটি কাজ() { jotokhon (সত্য) { break; } };", 0},
+	}
+
+	for i, tt := range tests {
+		got := parseErrors(tt.input)
+		if len(got) != tt.errsCount {
+			t.Fatalf("tests[%d] -> Expected %d errors, Got=%d (%v)", i, tt.errsCount, len(got), got)
+		}
+		for _, e := range got {
+			if _, ok := e.(*errs.OutsideLoopError); !ok {
+				t.Fatalf("tests[%d] -> Unexpected error %s", i, e.String())
+			}
+		}
+	}
+}
+
+func TestInterpolationErrors(t *testing.T) {
+	tests := []struct {
+		input string
+		line  int
+		col   int
+	}{
+		{`"ক {ক খ}"`, 1, 7},
+		{`"{(1 + 2}"`, 1, 9},
+		{"\"ক\n  {[1 2]}\"", 2, 7},
+	}
+
+	for i, tt := range tests {
+		got := parseErrors(tt.input)
+		if len(got) != 1 {
+			t.Fatalf("tests[%d] -> Expected 1 error, Got=%d (%v)", i, len(got), got)
+		}
+		tok := got[0].GetToken()
+		if tok.LineNo != tt.line || tok.Column != tt.col {
+			t.Fatalf("tests[%d] -> Position wrong -> Expected=%d:%d, Got=%d:%d (%s)", i, tt.line, tt.col, tok.LineNo, tok.Column, got[0].String())
+		}
+	}
+}
+
+func TestErrorRecovery(t *testing.T) {
+	type diag struct {
+		code string
+		line int
+		col  int
+	}
+	tests := []struct {
+		input    string
+		expected []diag
+		stmts    int
+	}{
+		{"ধরি = 5;\nধরি খ = 2;\nখ +* 3;\nদেখাও(খ", []diag{
+			{errs.EXPECTED_GOT, 1, 5}, {errs.NO_PREFIX_SUFFIX_FN, 3, 4}, {errs.EXPECTED_GOT, 4, 8},
+		}, 1},
+		{"যদি (ক { দেখাও(1) }\nধরি খ = (1 + ) * 2;\nfn(x) { x }\nদেখাও(খ)", []diag{
+			{errs.EXPECTED_GOT, 1, 8}, {errs.NO_PREFIX_SUFFIX_FN, 2, 14}, {errs.NO_EKTI_BEFORE_FN, 3, 1},
+		}, 1},
+		{"যতক্ষণ (সত্য) {\n  ধরি = 1;\n  ধরি ক = ;\n}\nধরি গ = {ক: 1, খ 2};\nদেখাও(গ)", []diag{
+			{errs.EXPECTED_GOT, 2, 7}, {errs.NO_PREFIX_SUFFIX_FN, 3, 11}, {errs.EXPECTED_GOT, 5, 18},
+		}, 2},
+		{"ধরি ক = [1,\n 2 +* 3\n];\nধরি খ = ", []diag{
+			{errs.NO_PREFIX_SUFFIX_FN, 2, 5}, {errs.NO_PREFIX_SUFFIX_FN, 4, 9},
+		}, 0},
+		{"যদি (ক) তাহলে { ধরি x = }\nধরি y = 2 +;\nধরি z = 3;", []diag{
+			{errs.NO_PREFIX_SUFFIX_FN, 1, 25}, {errs.NO_PREFIX_SUFFIX_FN, 2, 12},
+		}, 2},
+		{"যদি (ক) তাহলে { দেখাও(ক)\n", []diag{{errs.EXPECTED_GOT, 2, 1}}, 0},
+		{"}\nধরি ক = 1;", []diag{{errs.NO_PREFIX_SUFFIX_FN, 1, 1}}, 1},
+	}
+
+	for i, tt := range tests {
+		l := lexer.NewLexer(tt.input)
+		p := NewParser(&l)
+		prog := p.ParseProg()
+		got := p.GetErrors()
+
+		if len(got) != len(tt.expected) {
+			t.Fatalf("tests[%d] -> Expected %d errors, Got=%d (%v)", i, len(tt.expected), len(got), got)
+		}
+		for j, e := range got {
+			tok := e.GetToken()
+			want := tt.expected[j]
+			if e.Code() != want.code || tok.LineNo != want.line || tok.Column != want.col {
+				t.Fatalf("tests[%d] -> error %d -> Expected=%s %d:%d, Got=%s %d:%d (%s)", i, j, want.code, want.line, want.col, e.Code(), tok.LineNo, tok.Column, e.String())
+			}
+		}
+
+		if len(prog.Stmts) != tt.stmts {
+			t.Fatalf("tests[%d] -> Expected %d statements, Got=%d", i, tt.stmts, len(prog.Stmts))
+		}
+		for _, s := range prog.Stmts {
+			if s == nil {
+				t.Fatalf("tests[%d] -> nil statement in program", i)
+			}
+		}
+	}
+}
+
+func TestDocComments(t *testing.T) {
+	input := `# যোগ করে
+# দুটো সংখ্যা
+ধরি যোগ = একটি কাজ(ক, খ) { ক + খ };
+
+/* ভাগ */
+
+ধরি ভাগ = 1;
+ধরি গুণ = 2; # গুণ নয়
+ধরি বিয়োগ = 3;
+// ভাগশেষ দেয়
+//
+ধরি ভাগশেষ = 4;
+`
+	l := lexer.NewLexer(input)
+	p := NewParser(&l)
+	prog := p.ParseProg()
+
+	if len(p.GetErrors()) != 0 {
+		t.Fatalf("Unexpected errors %v", p.GetErrors())
+	}
+
+	expected := []string{"যোগ করে\nদুটো সংখ্যা", "", "", "", "ভাগশেষ দেয়\n"}
+	for i, doc := range expected {
+		let, ok := prog.Stmts[i].(*ast.LetStmt)
+		if !ok {
+			t.Fatalf("stmts[%d] -> not a let statement", i)
+		}
+		if let.Doc != doc {
+			t.Fatalf("stmts[%d] -> Doc wrong -> Expected=%q, Got=%q", i, doc, let.Doc)
+		}
+	}
+}
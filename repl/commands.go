@@ -0,0 +1,173 @@
+package repl
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode"
+	"vabna/ast"
+	"vabna/errs"
+	"vabna/evaluator"
+	"vabna/lexer"
+	"vabna/locale"
+	"vabna/number"
+	"vabna/object"
+	"vabna/parser"
+	"vabna/token"
+)
+
+// command is a REPL command like `:env`; `arg` is the text after its name
+type command func(s *session, name string, arg string)
+
+var commands = map[string]command{
+	"help":   helpCmd,
+	"env":    envCmd,
+	"type":   needsCode(typeCmd),
+	"ast":    needsCode(astCmd),
+	"tokens": needsCode(tokensCmd),
+	"load":   loadCmd,
+	"reset":  resetCmd,
+	"time":   needsCode(timeCmd),
+}
+
+// command runs the input `line` which started with `:`
+func (s *session) command(line string) {
+	name, arg := line, ""
+	if i := strings.IndexFunc(line, unicode.IsSpace); i >= 0 {
+		name, arg = line[:i], strings.TrimSpace(line[i:])
+	}
+
+	cmd, ok := commands[name]
+	if !ok {
+		s.say(errs.REPL_UNKNOWN_CMD, name)
+		return
+	}
+	cmd(s, name, arg)
+}
+
+// needsCode makes `cmd` complain when it is given no code
+func needsCode(cmd command) command {
+	return func(s *session, name string, arg string) {
+		if arg == "" {
+			s.say(errs.REPL_NEEDS_CODE, name)
+			return
+		}
+		cmd(s, name, arg)
+	}
+}
+
+func helpCmd(s *session, name string, arg string) {
+	s.say(errs.REPL_HELP)
+}
+
+// envCmd lists the names of the session with the first line of their values
+func envCmd(s *session, name string, arg string) {
+	names := s.env.Names()
+	if len(names) == 0 {
+		s.say(errs.REPL_NO_NAMES)
+		return
+	}
+
+	for _, n := range names {
+		val, _ := s.env.Get(n)
+		text := val.Inspect()
+		if i := strings.IndexByte(text, '\n'); i >= 0 {
+			text = text[:i] + " ..."
+		}
+		fmt.Fprintf(s.out, "%s = %s\n", n, text)
+	}
+}
+
+func typeCmd(s *session, name string, arg string) {
+	prog := s.parse(arg)
+	if prog == nil {
+		return
+	}
+
+	res := evaluator.Eval(prog, s.env)
+	if res == nil {
+		res = evaluator.NULL
+	}
+	if _, ok := res.(*object.Error); ok {
+		s.show(res)
+		return
+	}
+	fmt.Fprintln(s.out, errs.TypeName(string(res.Type())))
+}
+
+func astCmd(s *session, name string, arg string) {
+	if prog := s.parse(arg); prog != nil {
+		fmt.Fprint(s.out, ast.Dump(prog))
+	}
+}
+
+// tokensCmd lists the tokens of `arg` with their positions, even if
+// they do not make a program
+func tokensCmd(s *session, name string, arg string) {
+	lx := lexer.NewLexer(arg)
+	for {
+		tk := lx.NextToken()
+		if tk.Type == token.EOF {
+			return
+		}
+		fmt.Fprintf(s.out, "%-6s %-10s `%s`\n", tk.Pos(), tk.Type, tk.Literal)
+	}
+}
+
+// loadCmd runs a file in the session, so that its names can be used
+func loadCmd(s *session, name string, arg string) {
+	if arg == "" {
+		s.say(errs.REPL_NEEDS_FILE, name)
+		return
+	}
+
+	src, err := os.ReadFile(arg)
+	if err != nil {
+		s.say(errs.CLI_CANNOT_READ, arg, errs.Reason(err))
+		return
+	}
+
+	if spec, ok := locale.Pragma(string(src)); ok {
+		prev := locale.Active()
+		defer locale.Use(prev...)
+		if err := locale.Select(spec, filepath.Dir(arg)); err != nil {
+			s.say(errs.CLI_BAD_LOCALE, spec, err)
+			return
+		}
+	}
+
+	lx := lexer.NewFileLexer(arg, string(src))
+	p := parser.NewParser(&lx)
+	prog := p.ParseProg()
+	if len(p.GetErrors()) != 0 {
+		ShowParseErrors(s.out, p.GetErrors())
+		return
+	}
+
+	if res := evaluator.Eval(prog, s.env); res != nil && res.Type() == object.ERR_OBJ {
+		s.show(res)
+	}
+}
+
+func resetCmd(s *session, name string, arg string) {
+	s.env = object.NewEnv()
+	evaluator.Reset()
+	number.OutputNumerals = s.numerals
+	s.say(errs.REPL_RESET)
+}
+
+func timeCmd(s *session, name string, arg string) {
+	prog := s.parse(arg)
+	if prog == nil {
+		return
+	}
+
+	start := time.Now()
+	res := evaluator.Eval(prog, s.env)
+	took := time.Since(start)
+
+	s.show(res)
+	s.say(errs.REPL_TIME, took.Round(time.Microsecond))
+}
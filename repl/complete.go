@@ -0,0 +1,144 @@
+package repl
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"vabna/evaluator"
+	"vabna/lexer"
+	"vabna/object"
+	"vabna/token"
+)
+
+// complete returns the words which can take the place of the text
+// between `start` and the cursor at `pos` in `line`: the keywords,
+// builtins and names of `env` and the scopes around it which begin
+// with the word before the cursor, the members of a module after
+// `module.`, or the keys of a hash after `hash[`
+func complete(line []rune, pos int, env *object.Env) (start int, words []string) {
+	if start, words, ok := completeKey(line, pos, env); ok {
+		return start, words
+	}
+
+	start = wordStart(line, pos)
+	word := lexer.Normalize(string(line[start:pos]))
+	if word == "" {
+		return start, nil
+	}
+
+	var names []string
+	if start > 0 && line[start-1] == '.' {
+		mod, ok := lookup(line, start-1, env).(*object.Module)
+		if !ok {
+			return start, nil
+		}
+		names = mod.Env.Names()
+	} else {
+		for kw := range token.Keywords {
+			names = append(names, kw)
+		}
+		names = append(names, evaluator.BuiltinSpellings()...)
+		for e := env; e != nil; e = e.Outer() {
+			names = append(names, e.Names()...)
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, n := range names {
+		if strings.HasPrefix(n, word) && !seen[n] {
+			seen[n] = true
+			words = append(words, n)
+		}
+	}
+	sort.Strings(words)
+	return start, words
+}
+
+// completeKey completes the key of a hash after `hash[` or `hash["`,
+// with the closing `]`; false if the cursor is not in such a place
+func completeKey(line []rune, pos int, env *object.Env) (int, []string, bool) {
+	i := pos
+	for i > 0 && line[i-1] != '"' && line[i-1] != '[' && line[i-1] != ']' {
+		i--
+	}
+	quoted := i > 0 && line[i-1] == '"'
+	if quoted {
+		i--
+	}
+	if i == 0 || line[i-1] != '[' || !quoted && i != pos {
+		return 0, nil, false
+	}
+
+	hash, ok := lookup(line, i-1, env).(*object.Hash)
+	if !ok {
+		return 0, nil, false
+	}
+
+	prefix := ""
+	if quoted {
+		prefix = string(line[i+1 : pos])
+	}
+
+	words := []string{}
+	for _, k := range hash.Order {
+		switch key := hash.Pairs[k].Key.(type) {
+		case *object.String:
+			if strings.HasPrefix(key.Value, prefix) {
+				words = append(words, quote(key.Value)+"]")
+			}
+		default:
+			if !quoted {
+				words = append(words, key.Inspect()+"]")
+			}
+		}
+	}
+	return i, words, true
+}
+
+// lookup returns the value of the name which ends at `end` in `line`
+func lookup(line []rune, end int, env *object.Env) object.Obj {
+	name := lexer.Normalize(string(line[wordStart(line, end):end]))
+	if name == "" {
+		return nil
+	}
+	val, _ := env.Get(name)
+	return val
+}
+
+// wordStart returns where the name which ends at `end` starts
+func wordStart(line []rune, end int) int {
+	i := end
+	for i > 0 && isWordRune(line[i-1]) {
+		i--
+	}
+	return i
+}
+
+// isWordRune reports whether `r` can be part of a name
+func isWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) ||
+		unicode.In(r, unicode.Mn, unicode.Mc, unicode.Nl) || r == '\u200C' || r == '\u200D'
+}
+
+// quote writes `s` as a string literal
+func quote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "{", `\{`, "}", `\}`, "\n", `\n`, "\t", `\t`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// commonPrefix returns the longest text all of `words` begin with
+func commonPrefix(words []string) string {
+	if len(words) == 0 {
+		return ""
+	}
+	prefix := []rune(words[0])
+	for _, w := range words[1:] {
+		rs := []rune(w)
+		n := 0
+		for n < len(prefix) && n < len(rs) && prefix[n] == rs[n] {
+			n++
+		}
+		prefix = prefix[:n]
+	}
+	return string(prefix)
+}
@@ -0,0 +1,354 @@
+package repl
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode"
+)
+
+// errInterrupt is returned by ReadLine when Ctrl-C is pressed
+var errInterrupt = errors.New("interrupted")
+
+// lineReader reads the REPL's input a line at a time
+type lineReader interface {
+	ReadLine(prompt string) (string, error)
+}
+
+// newReader returns a line editor when `in` is a terminal which can
+// be put in raw mode, and a plain reader of lines otherwise
+func newReader(in io.Reader, out io.Writer) lineReader {
+	if f, ok := in.(*os.File); ok {
+		fd := int(f.Fd())
+		if restore, err := makeRaw(fd); err == nil {
+			restore()
+			return &editor{
+				in:      bufio.NewReader(in),
+				out:     out,
+				history: LoadHistory(HistoryPath()),
+				raw:     func() (func(), error) { return makeRaw(fd) },
+			}
+		}
+	}
+	return &plainReader{in: bufio.NewReader(in), out: out}
+}
+
+// plainReader reads lines from pipes, files and terminals which
+// cannot be put in raw mode
+type plainReader struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+func (r *plainReader) ReadLine(prompt string) (string, error) {
+	io.WriteString(r.out, prompt)
+	line, err := r.in.ReadString('\n')
+	if err != nil && line == "" {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// editor reads lines from a terminal in raw mode, with the usual
+// keys for moving around the line and through the history.
+//
+// The cursor moves over whole grapheme clusters, so `ক্ষি` is one
+// step for the arrow keys; Backspace removes one character at a
+// time, so a mistyped vowel sign can be fixed without typing the
+// consonant again.
+type editor struct {
+	in      *bufio.Reader
+	out     io.Writer
+	history *History
+	// raw puts the terminal in raw mode and returns what undoes it
+	raw func() (func(), error)
+	// complete finds the words which can replace line[start:pos]
+	complete func(line []rune, pos int) (start int, words []string)
+
+	prompt string
+	buf    []rune
+	pos    int
+
+	// hist is the index of the history line shown, len(Lines) for
+	// the new line; draft keeps the new line while browsing
+	hist  int
+	draft []rune
+}
+
+func ctrl(c rune) rune { return c & 0x1f }
+
+func (e *editor) ReadLine(prompt string) (string, error) {
+	restore, err := e.raw()
+	if err != nil {
+		return "", err
+	}
+	defer restore()
+
+	e.prompt = prompt
+	e.buf, e.pos = nil, 0
+	e.hist, e.draft = len(e.history.Lines), nil
+	e.refresh()
+
+	for {
+		r, _, err := e.in.ReadRune()
+		if err != nil {
+			return "", err
+		}
+
+		switch r {
+		case '\r', '\n':
+			e.pos = len(e.buf)
+			e.refresh()
+			io.WriteString(e.out, "\n")
+			line := string(e.buf)
+			e.history.Add(line)
+			return line, nil
+		case ctrl('C'):
+			io.WriteString(e.out, "^C\n")
+			return "", errInterrupt
+		case ctrl('D'):
+			if len(e.buf) == 0 {
+				io.WriteString(e.out, "\n")
+				return "", io.EOF
+			}
+			e.delete()
+		case ctrl('A'):
+			e.pos = 0
+		case ctrl('E'):
+			e.pos = len(e.buf)
+		case ctrl('B'):
+			e.pos = prevBoundary(e.buf, e.pos)
+		case ctrl('F'):
+			e.pos = nextBoundary(e.buf, e.pos)
+		case ctrl('H'), 127:
+			e.backspace()
+		case ctrl('K'):
+			e.buf = e.buf[:e.pos]
+		case ctrl('U'):
+			e.buf = append([]rune{}, e.buf[e.pos:]...)
+			e.pos = 0
+		case ctrl('W'):
+			start := e.wordStart()
+			e.buf = append(e.buf[:start], e.buf[e.pos:]...)
+			e.pos = start
+		case ctrl('P'):
+			e.browse(-1)
+		case ctrl('N'):
+			e.browse(1)
+		case ctrl('L'):
+			io.WriteString(e.out, "\x1b[H\x1b[2J")
+		case '\t':
+			e.tab()
+		case 27:
+			e.escape()
+		default:
+			if r >= ' ' {
+				e.insert([]rune{r})
+			}
+		}
+		e.refresh()
+	}
+}
+
+// escape handles the keys which send escape sequences, like the
+// arrows, and Alt-b and Alt-f
+func (e *editor) escape() {
+	r, _, err := e.in.ReadRune()
+	if err != nil {
+		return
+	}
+
+	switch r {
+	case 'b':
+		e.pos = e.wordStart()
+		return
+	case 'f':
+		e.pos = e.wordEnd()
+		return
+	case '[', 'O':
+	default:
+		return
+	}
+
+	// ESC [ params final, like ESC [ A or ESC [ 3 ~
+	params := ""
+	for {
+		r, _, err = e.in.ReadRune()
+		if err != nil {
+			return
+		}
+		if r < '0' || r > '?' {
+			break
+		}
+		params += string(r)
+	}
+
+	switch {
+	case r == 'A':
+		e.browse(-1)
+	case r == 'B':
+		e.browse(1)
+	case r == 'C':
+		e.pos = nextBoundary(e.buf, e.pos)
+	case r == 'D':
+		e.pos = prevBoundary(e.buf, e.pos)
+	case r == 'H', r == '~' && (params == "1" || params == "7"):
+		e.pos = 0
+	case r == 'F', r == '~' && (params == "4" || params == "8"):
+		e.pos = len(e.buf)
+	case r == '~' && params == "3":
+		e.delete()
+	}
+}
+
+// tab completes the word before the cursor. With several choices it
+// puts in as much as they share, and lists them once that is all in;
+// at the start of a word it indents.
+func (e *editor) tab() {
+	start, words := e.pos, []string(nil)
+	if e.complete != nil {
+		start, words = e.complete(e.buf, e.pos)
+	}
+
+	if len(words) == 0 {
+		if start == e.pos {
+			e.insert([]rune("    "))
+		}
+		return
+	}
+
+	if common := commonPrefix(words); common != string(e.buf[start:e.pos]) {
+		rest := append([]rune(common), e.buf[e.pos:]...)
+		e.buf = append(e.buf[:start:start], rest...)
+		e.pos = start + len([]rune(common))
+		return
+	}
+
+	if len(words) > 1 {
+		io.WriteString(e.out, "\n"+strings.Join(words, "  ")+"\n")
+	}
+}
+
+func (e *editor) insert(rs []rune) {
+	buf := append([]rune{}, e.buf[:e.pos]...)
+	buf = append(buf, rs...)
+	e.buf = append(buf, e.buf[e.pos:]...)
+	e.pos += len(rs)
+}
+
+// backspace removes the character before the cursor
+func (e *editor) backspace() {
+	if e.pos == 0 {
+		return
+	}
+	e.buf = append(e.buf[:e.pos-1], e.buf[e.pos:]...)
+	e.pos--
+}
+
+// delete removes the grapheme cluster after the cursor
+func (e *editor) delete() {
+	end := nextBoundary(e.buf, e.pos)
+	e.buf = append(e.buf[:e.pos], e.buf[end:]...)
+}
+
+// wordStart returns where the word before the cursor starts
+func (e *editor) wordStart() int {
+	i := e.pos
+	for i > 0 && unicode.IsSpace(e.buf[i-1]) {
+		i--
+	}
+	for i > 0 && !unicode.IsSpace(e.buf[i-1]) {
+		i--
+	}
+	return i
+}
+
+// wordEnd returns where the word after the cursor ends
+func (e *editor) wordEnd() int {
+	i := e.pos
+	for i < len(e.buf) && unicode.IsSpace(e.buf[i]) {
+		i++
+	}
+	for i < len(e.buf) && !unicode.IsSpace(e.buf[i]) {
+		i++
+	}
+	return i
+}
+
+// browse shows the history line `step` lines older or newer
+func (e *editor) browse(step int) {
+	to := e.hist + step
+	if to < 0 || to > len(e.history.Lines) {
+		return
+	}
+
+	if e.hist == len(e.history.Lines) {
+		e.draft = e.buf
+	}
+	e.hist = to
+
+	if to == len(e.history.Lines) {
+		e.buf = e.draft
+	} else {
+		e.buf = []rune(e.history.Lines[to])
+	}
+	e.pos = len(e.buf)
+}
+
+// refresh draws the line again. The cursor is put in place by
+// drawing the text before it once more, rather than by counting
+// columns, as terminals differ in how wide they draw Bengali
+// conjuncts and vowel signs.
+func (e *editor) refresh() {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\r%s%s\x1b[K", e.prompt, string(e.buf))
+	if e.pos < len(e.buf) {
+		fmt.Fprintf(&b, "\r%s%s", e.prompt, string(e.buf[:e.pos]))
+	}
+	io.WriteString(e.out, b.String())
+}
+
+// joinsPrevious reports whether `r` is drawn together with the
+// character before it: vowel signs, other combining marks, ZWNJ and ZWJ
+func joinsPrevious(r rune) bool {
+	return unicode.In(r, unicode.Mn, unicode.Mc, unicode.Me) || r == '\u200C' || r == '\u200D'
+}
+
+// joinsNext reports whether the character after `r` is drawn
+// together with it: after a virama like `্` or ZWJ
+func joinsNext(r rune) bool {
+	switch r {
+	case '्', '্', '੍', '્', '୍', '்', '్', '್', '്', '\u200D':
+		return true
+	}
+	return false
+}
+
+// isBoundary reports whether a grapheme cluster starts at `buf[i]`
+func isBoundary(buf []rune, i int) bool {
+	if i <= 0 || i >= len(buf) {
+		return true
+	}
+	return !joinsPrevious(buf[i]) && !joinsNext(buf[i-1])
+}
+
+func prevBoundary(buf []rune, i int) int {
+	for i--; i > 0 && !isBoundary(buf, i); i-- {
+	}
+	if i < 0 {
+		return 0
+	}
+	return i
+}
+
+func nextBoundary(buf []rune, i int) int {
+	for i++; i < len(buf) && !isBoundary(buf, i); i++ {
+	}
+	if i > len(buf) {
+		return len(buf)
+	}
+	return i
+}
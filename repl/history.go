@@ -0,0 +1,92 @@
+package repl
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HISTORY_FILE is the name of the history file in the home directory
+const HISTORY_FILE = ".vabna_history"
+
+// MAX_HISTORY is the number of lines the history keeps
+const MAX_HISTORY = 1000
+
+// History is the list of lines typed into the REPL, oldest first,
+// kept in a file between sessions
+type History struct {
+	Lines []string
+	path  string
+}
+
+// HistoryPath returns the path of the history file; VABNA_HISTORY
+// overrides ~/.vabna_history, and an empty path keeps no file
+func HistoryPath() string {
+	if path, ok := os.LookupEnv("VABNA_HISTORY"); ok {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, HISTORY_FILE)
+}
+
+// LoadHistory reads the history in the file `path`; a file which
+// does not exist yet gives an empty history
+func LoadHistory(path string) *History {
+	h := &History{path: path}
+	if path == "" {
+		return h
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return h
+	}
+	defer f.Close()
+
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		h.Lines = append(h.Lines, sc.Text())
+	}
+
+	if len(h.Lines) > MAX_HISTORY {
+		h.Lines = h.Lines[len(h.Lines)-MAX_HISTORY:]
+		h.save()
+	}
+	return h
+}
+
+// Add puts `line` at the end of the history and of its file, unless
+// it is blank or the same as the last line
+func (h *History) Add(line string) {
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	if n := len(h.Lines); n > 0 && h.Lines[n-1] == line {
+		return
+	}
+
+	h.Lines = append(h.Lines, line)
+	if len(h.Lines) > MAX_HISTORY {
+		h.Lines = h.Lines[1:]
+	}
+
+	if h.path == "" {
+		return
+	}
+	f, err := os.OpenFile(h.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+	f.WriteString(line + "\n")
+}
+
+// save writes the whole history over its file
+func (h *History) save() {
+	content := strings.Join(h.Lines, "\n") + "\n"
+	os.WriteFile(h.path, []byte(content), 0600)
+}
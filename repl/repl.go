@@ -1,58 +1,149 @@
 package repl
 
 import (
-	"bufio"
-	"fmt"
 	"io"
+	"strings"
+	"vabna/ast"
 	"vabna/errs"
 	"vabna/evaluator"
 	"vabna/lexer"
+	"vabna/number"
 	"vabna/object"
 	"vabna/parser"
+	"vabna/token"
 )
 
 const PROMPT = "-> "
 
+// CONT_PROMPT is shown for the lines of an input which is not complete yet
+const CONT_PROMPT = ".. "
+
+// session is the state of one run of the REPL
+type session struct {
+	env *object.Env
+	out io.Writer
+	// numerals are the digits numbers were printed with at the start
+	numerals number.Numerals
+}
+
 func Repl(in io.Reader, out io.Writer) {
-	scanner := bufio.NewScanner(in)
-	env := object.NewEnv()
+	rd := newReader(in, out)
+	s := &session{env: object.NewEnv(), out: out, numerals: number.OutputNumerals}
+	if ed, ok := rd.(*editor); ok {
+		ed.complete = func(line []rune, pos int) (int, []string) {
+			return complete(line, pos, s.env)
+		}
+	}
 
 	for {
-		fmt.Fprintf(out, PROMPT)
-		scanned := scanner.Scan()
-
-		if !scanned {
+		input, err := readInput(rd)
+		if err == errInterrupt {
+			continue
+		}
+		if err != nil {
 			return
 		}
+		input = strings.TrimSpace(input)
+		if input == "" {
+			continue
+		}
 
-		input := scanner.Text()
-		rlexer := lexer.NewLexer(input)
-        //fmt.Println(rlexer.)
-/*
-        for !rlexer.AtEOF(){
-            fmt.Println(rlexer.NextToken().Type)
-        }
-*/
-		p := parser.NewParser(&rlexer)
+		if strings.HasPrefix(input, ":") {
+			s.command(input[1:])
+			continue
+		}
 
-		prog := p.ParseProg()
-        fmt.Println(prog.Stmts)
+		if prog := s.parse(input); prog != nil {
+			s.show(evaluator.Eval(prog, s.env))
+		}
+	}
+}
 
-		if len(p.GetErrors()) != 0 {
-			ShowParseErrors(out, p.GetErrors())
-			continue
+// parse parses `src`, showing its errors if it has any
+func (s *session) parse(src string) *ast.Program {
+	lx := lexer.NewLexer(src)
+	p := parser.NewParser(&lx)
+	prog := p.ParseProg()
+
+	if len(p.GetErrors()) != 0 {
+		ShowParseErrors(s.out, p.GetErrors())
+		return nil
+	}
+	return prog
+}
+
+// show prints the value of an input
+func (s *session) show(res object.Obj) {
+	if res != nil {
+		io.WriteString(s.out, res.Inspect())
+		io.WriteString(s.out, "\n")
+	}
+}
+
+// say prints a message of the REPL
+func (s *session) say(key string, a ...interface{}) {
+	io.WriteString(s.out, errs.Format(key, a...)+"\n")
+}
+
+// readInput reads lines until they make a complete input; Ctrl-C
+// drops the lines read so far. At the end of the input whatever was
+// read is returned, so that its errors are shown.
+func readInput(rd lineReader) (string, error) {
+	var lines []string
+	prompt := PROMPT
+
+	for {
+		line, err := rd.ReadLine(prompt)
+		if err == io.EOF && len(lines) != 0 {
+			return strings.Join(lines, "\n"), nil
 		}
-		evals := evaluator.Eval(prog, env)
-		if evals != nil {
-			//fmt.Println(evals)
-			io.WriteString(out, evals.Inspect())
-			io.WriteString(out, "\n")
+		if err != nil {
+			return "", err
 		}
+
+		lines = append(lines, line)
+		input := strings.Join(lines, "\n")
+		if !incomplete(input) {
+			return input, nil
+		}
+		prompt = CONT_PROMPT
 	}
 }
 
-func ShowParseErrors(out io.Writer, errs []errs.ParserError) {
-	for _, msg := range errs {
-		io.WriteString(out, "\t ERR >"+msg.String()+"\n")
+// incomplete reports whether `src` ends inside a `{`, `(` or `[`, a
+// string or a comment, so that more lines are needed
+func incomplete(src string) bool {
+	lx := lexer.NewLexer(src)
+	depth := 0
+
+	for {
+		tk := lx.NextToken()
+		switch tk.Type {
+		case token.EOF:
+			return depth > 0
+		case token.LBRACE, token.LPAREN, token.LS_BRACKET:
+			depth++
+		case token.RBRACE, token.RPAREN, token.RS_BRACKET:
+			// too many closing brackets will not be fixed by more lines
+			if depth--; depth < 0 {
+				return false
+			}
+		case token.ILLEGAL:
+			// strings and comments which are never closed take
+			// the rest of the input
+			if strings.HasPrefix(tk.Literal, "\"") || strings.HasPrefix(tk.Literal, "`") || tk.Literal == "/*" {
+				return true
+			}
+		}
+	}
+}
+
+func ShowParseErrors(out io.Writer, perrs []errs.ParserError) {
+	for _, msg := range perrs {
+		where := ""
+		if pos := msg.GetToken().Pos(); pos.IsValid() {
+			where = pos.String() + ": "
+		}
+		io.WriteString(out, "\t "+errs.Msg(errs.ERROR)+" ["+msg.Code()+"] > "+where+msg.String()+"\n")
 	}
 }
@@ -0,0 +1,319 @@
+package repl
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"vabna/errs"
+	"vabna/evaluator"
+	"vabna/object"
+)
+
+func TestIncomplete(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected bool
+	}{
+		{"1 + 2", false},
+		{"ধরি যোগ = একটি কাজ(ক, খ) {", true},
+		{"ধরি যোগ = একটি কাজ(ক, খ) {\n ফেরাও ক + খ;\n}", false},
+		{"দেখাও(1,", true},
+		{"[1, [2,", true},
+		{"\"কেমন আছো", true},
+		{"`কাঁচা", true},
+		{"\"{1 + ", true},
+		{"/* মন্তব্য", true},
+		{"# {", false},
+		{"// {", false},
+		{"\"\\{\"", false},
+		{"1 + 2)", false},
+		{"1) + (", false},
+	}
+
+	for i, tt := range tests {
+		if got := incomplete(tt.input); got != tt.expected {
+			t.Fatalf("tests[%d] -> Expected=%v, Got=%v", i, tt.expected, got)
+		}
+	}
+}
+
+func TestRepl(t *testing.T) {
+	input := "ধরি যোগ = একটি কাজ(ক, খ) {\n    ফেরাও ক + খ;\n}\nযোগ(1,\n2)\n\"ক\nখ\"\n"
+	var out strings.Builder
+	Repl(strings.NewReader(input), &out)
+
+	expected := "-> .. .. -> .. 3\n-> .. ক\nখ\n-> "
+	if out.String() != expected {
+		t.Fatalf("Expected=%q, Got=%q", expected, out.String())
+	}
+}
+
+func newTestEditor(keys string, lines ...string) *editor {
+	return &editor{
+		in:      bufio.NewReader(strings.NewReader(keys)),
+		out:     io.Discard,
+		history: &History{Lines: lines},
+		raw:     func() (func(), error) { return func() {}, nil },
+	}
+}
+
+func TestEditor(t *testing.T) {
+	tests := []struct {
+		keys     string
+		history  []string
+		expected string
+	}{
+		{"abc\r", nil, "abc"},
+		{"abc\x7f\x7fd\r", nil, "ad"},
+		{"ac\x1b[Db\r", nil, "abc"},
+		{"bc\x01a\x05d\r", nil, "abcd"},
+		{"abc\x1b[D\x1b[D\x1b[3~\r", nil, "ac"},
+		{"abc\x02\x02\x0b\r", nil, "a"},
+		{"ab cd\x15x\r", nil, "x"},
+		{"ধরি ক\x17খ\r", nil, "ধরি খ"},
+		// কি is one step for the cursor but two for Backspace
+		{"কি\x1b[Dখ\r", nil, "খকি"},
+		{"কি\x7fা\r", nil, "কা"},
+		{"ক্ষি\x1b[Dখ\r", nil, "খক্ষি"},
+		{"র‍্য\x1b[D\x1b[3~\r", nil, ""},
+		{"\x1b[A\r", []string{"১", "২"}, "২"},
+		{"\x1b[A\x1b[A\r", []string{"১", "২"}, "১"},
+		{"নতুন\x10\x0e\r", []string{"১"}, "নতুন"},
+		{"\x1b[A\x1b[A\x1b[A\x1b[B\r", []string{"১", "২"}, "২"},
+	}
+
+	for i, tt := range tests {
+		e := newTestEditor(tt.keys, tt.history...)
+		got, err := e.ReadLine(PROMPT)
+		if err != nil {
+			t.Fatalf("tests[%d] -> Unexpected error %s", i, err)
+		}
+		if got != tt.expected {
+			t.Fatalf("tests[%d] -> Expected=%q, Got=%q", i, tt.expected, got)
+		}
+	}
+}
+
+func TestBoundary(t *testing.T) {
+	tests := []struct {
+		buf  string
+		pos  int
+		prev int
+		next int
+	}{
+		// ক্ষি is ক, virama, ষ and a vowel sign
+		{"ক্ষিখ", 0, 0, 4},
+		{"ক্ষিখ", 2, 0, 4},
+		{"ক্ষিখ", 4, 0, 5},
+		{"ক্ষিখ", 5, 4, 5},
+		// র‍্য is র, ZWJ, virama and য
+		{"র‍্য", 0, 0, 4},
+		{"র‍্য", 4, 0, 4},
+		{"👩‍💻ক", 0, 0, 3},
+		{"👩‍💻ক", 3, 0, 4},
+		{"ক‌ষ", 0, 0, 2},
+		{"", 0, 0, 0},
+	}
+
+	for i, tt := range tests {
+		buf := []rune(tt.buf)
+		if got := prevBoundary(buf, tt.pos); got != tt.prev {
+			t.Fatalf("tests[%d] -> prevBoundary Expected=%d, Got=%d", i, tt.prev, got)
+		}
+		if got := nextBoundary(buf, tt.pos); got != tt.next {
+			t.Fatalf("tests[%d] -> nextBoundary Expected=%d, Got=%d", i, tt.next, got)
+		}
+	}
+}
+
+func TestErase(t *testing.T) {
+	tests := []struct {
+		buf         string
+		pos         int
+		delete      bool
+		expected    string
+		expectedPos int
+	}{
+		{"ক্ষি", 4, false, "ক্ষ", 3},
+		{"ক্ষ", 3, false, "ক্", 2},
+		{"র‍্য", 4, false, "র‍্", 3},
+		{"👩‍💻", 3, false, "👩‍", 2},
+		{"ক", 0, false, "ক", 0},
+		{"ক্ষিখ", 0, true, "খ", 0},
+		{"খক্ষি", 1, true, "খ", 1},
+		{"র‍্যক", 0, true, "ক", 0},
+		{"👩‍💻ক", 0, true, "ক", 0},
+		{"ক", 1, true, "ক", 1},
+	}
+
+	for i, tt := range tests {
+		e := &editor{buf: []rune(tt.buf), pos: tt.pos}
+		if tt.delete {
+			e.delete()
+		} else {
+			e.backspace()
+		}
+		if string(e.buf) != tt.expected || e.pos != tt.expectedPos {
+			t.Fatalf("tests[%d] -> Expected=%q at %d, Got=%q at %d", i, tt.expected, tt.expectedPos, string(e.buf), e.pos)
+		}
+	}
+}
+
+func TestEditorKeys(t *testing.T) {
+	if _, err := newTestEditor("abc\x03").ReadLine(PROMPT); err != errInterrupt {
+		t.Fatalf("Expected errInterrupt for Ctrl-C, Got=%v", err)
+	}
+	if _, err := newTestEditor("\x04").ReadLine(PROMPT); err != io.EOF {
+		t.Fatalf("Expected EOF for Ctrl-D, Got=%v", err)
+	}
+	if got, _ := newTestEditor("ab\x01\x04\r").ReadLine(PROMPT); got != "b" {
+		t.Fatalf("Expected Ctrl-D to delete, Got=%q", got)
+	}
+}
+
+func TestHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), HISTORY_FILE)
+
+	h := LoadHistory(path)
+	h.Add("ধরি ক = 1;")
+	h.Add("ধরি ক = 1;")
+	h.Add("   ")
+	h.Add("ক + 1")
+
+	got := LoadHistory(path).Lines
+	if strings.Join(got, "|") != "ধরি ক = 1;|ক + 1" {
+		t.Fatalf("Expected two lines, Got=%q", got)
+	}
+
+	lines := strings.Repeat("ক\n", MAX_HISTORY+10)
+	os.WriteFile(path, []byte(lines), 0600)
+	if n := len(LoadHistory(path).Lines); n != MAX_HISTORY {
+		t.Fatalf("Expected %d lines, Got=%d", MAX_HISTORY, n)
+	}
+}
+
+func TestCommands(t *testing.T) {
+	defer errs.SetLang("bn")
+	errs.SetLang("en")
+
+	dir := t.TempDir()
+	lib := filepath.Join(dir, "lib.vab")
+	os.WriteFile(lib, []byte("ধরি দ্বিগুণ = একটি কাজ(x) { ফেরাও x * 2; };\n"), 0600)
+
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{":env", "no names yet\n"},
+		{"ধরি ক = [1, 2];\n:env", "ক = [1, 2]\n"},
+		{":type 1 + 2", "number\n"},
+		{":type \"ক\"", "string\n"},
+		{":type ধরি ক = 1;", "null\n"},
+		{":ast 1 + 2", "Program 1:1 `1`\n  Stmts:\n    ExprStmt 1:1 `1`\n      Expr: InfixExpr 1:1 `+`\n        Left: NumberLit 1:1 `1`\n        Right: NumberLit 1:5 `2`\n"},
+		{":ast ধরি ক = {\"a\": 1};", "LetStmt 1:1 `ধরি`\n      Name: Identifier 1:5 `ক`\n      Value: HashLit 1:9 `{`\n        Key: StringLit 1:10 `a`\n          Value: NumberLit 1:15 `1`\n"},
+		{":tokens ধরি ক", "1:1    LET        `ধরি`\n1:5    IDENT      `ক`\n"},
+		{":load " + lib + "\nদ্বিগুণ(21)", "42\n"},
+		{"ধরি ক = 1;\n:reset\n:env", "forgot every name\n-> no names yet\n"},
+		{"অঙ্ক(\"bn\");\n:reset\n12", "forgot every name\n-> 12\n"},
+		{":time 1 + 1", "2\ntime: "},
+		{":type", "`:type` needs some code\n"},
+		{":load", "`:load` needs a file name\n"},
+		{":foo", "unknown command `:foo`; see `:help`\n"},
+		{":help", ":reset"},
+	}
+
+	for i, tt := range tests {
+		var out strings.Builder
+		Repl(strings.NewReader(tt.input+"\n"), &out)
+		if !strings.Contains(out.String(), tt.expected) {
+			t.Fatalf("tests[%d] -> Expected %q in %q", i, tt.expected, out.String())
+		}
+	}
+
+	// a module changed after it was imported is read again after :reset
+	mod := filepath.Join(dir, "m.vab")
+	os.WriteFile(mod, []byte("ধরি ম = 1;"), 0600)
+	Repl(strings.NewReader("আনো \""+mod+"\"; m.ম\n"), io.Discard)
+	os.WriteFile(mod, []byte("ধরি ম = 99;"), 0600)
+
+	var out strings.Builder
+	Repl(strings.NewReader(":reset\nআনো \""+mod+"\"; m.ম\n"), &out)
+	if !strings.Contains(out.String(), "99") {
+		t.Fatalf("Expected the changed module after :reset, Got=%q", out.String())
+	}
+}
+
+func TestComplete(t *testing.T) {
+	env := object.NewEnv()
+	prog := (&session{env: env, out: io.Discard}).parse(`
+ধরি ব্যক্তি = {"নাম": "পলাশ", "নগর": "কলকাতা", "বয়স": 20, 1: "এক", "\{x\}": 0};
+ধরি নামতা = [2, 4];
+ধরি দেখা = 1;`)
+	evaluator.Eval(prog, env)
+	mod := &object.Module{Name: "গণিত", Env: object.NewEnv()}
+	mod.Env.Set("যোগ", evaluator.NULL)
+	env.Set("গণিত", mod)
+	inner := object.NewEnclosedEnv(env)
+	inner.Set("নামহীন", evaluator.NULL)
+
+	tests := []struct {
+		line     string
+		start    int
+		expected []string
+	}{
+		{"নামত", 0, []string{"নামতা"}},
+		{"দেখ", 0, []string{"দেখা", "দেখাও"}},
+		{"ধর", 0, []string{"ধরি"}},
+		{"1 + ব্য", 4, []string{"ব্যক্তি"}},
+		{"নাম", 0, []string{"নামতা", "নামহীন"}},
+		{"xyz", 0, nil},
+		{"ব্যক্তি[", 8, []string{`"নাম"]`, `"নগর"]`, `"বয়স"]`, "1]", `"\{x\}"]`}},
+		{`ব্যক্তি["ন`, 8, []string{`"নাম"]`, `"নগর"]`}},
+		{"নামতা[", 6, nil},
+		{"গণিত.য", 5, []string{"যোগ"}},
+		{"নামতা.য", 6, nil},
+		{"", 0, nil},
+	}
+
+	for i, tt := range tests {
+		line := []rune(tt.line)
+		start, words := complete(line, len(line), inner)
+		if start != tt.start {
+			t.Fatalf("tests[%d] -> Expected start=%d, Got=%d", i, tt.start, start)
+		}
+		if strings.Join(words, "|") != strings.Join(tt.expected, "|") {
+			t.Fatalf("tests[%d] -> Expected=%q, Got=%q", i, tt.expected, words)
+		}
+	}
+}
+
+func TestTab(t *testing.T) {
+	env := object.NewEnv()
+	env.Set("গণনা", evaluator.NULL)
+	env.Set("গণিত", evaluator.NULL)
+
+	tests := []struct {
+		keys     string
+		expected string
+	}{
+		{"গ\t\r", "গণ"},
+		{"গ\t\tন\t\r", "গণনা"},
+		{"(গণন\t)\x01\x06\x06\x06\t\r", "(গণনা)"},
+		{"\tx\r", "    x"},
+		{"xyz\t\r", "xyz"},
+	}
+
+	for i, tt := range tests {
+		e := newTestEditor(tt.keys)
+		e.complete = func(line []rune, pos int) (int, []string) {
+			return complete(line, pos, env)
+		}
+		got, _ := e.ReadLine(PROMPT)
+		if got != tt.expected {
+			t.Fatalf("tests[%d] -> Expected=%q, Got=%q", i, tt.expected, got)
+		}
+	}
+}
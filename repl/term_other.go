@@ -0,0 +1,11 @@
+//go:build !linux && !darwin && !dragonfly && !freebsd && !netbsd && !openbsd
+
+package repl
+
+import "errors"
+
+// makeRaw fails where raw mode is not supported; the REPL then reads
+// whole lines as the terminal gives them
+func makeRaw(fd int) (func(), error) {
+	return nil, errors.New("raw mode is not supported")
+}
@@ -0,0 +1,59 @@
+package token
+
+import (
+	"fmt"
+	"strings"
+)
+
+// File is a named piece of source code. Every token keeps a pointer
+// to the file it was read from, so that errors can quote the offending line.
+type File struct {
+	Name  string
+	lines []string
+}
+
+func NewFile(name string, src string) *File {
+	return &File{Name: name, lines: strings.Split(src, "\n")}
+}
+
+// Line returns the n-th line (starting at 1) of the file
+func (f *File) Line(n int) (string, bool) {
+	if f == nil || n < 1 || n > len(f.lines) {
+		return "", false
+	}
+	return strings.TrimRight(f.lines[n-1], "\r"), true
+}
+
+// Pos is a position in a source file
+type Pos struct {
+	File   *File
+	Offset int // rune offset, starting at 0
+	Line   int // starting at 1
+	Column int // starting at 1, counted in runes
+}
+
+func (p Pos) IsValid() bool { return p.Line > 0 }
+
+// Advance returns the position `n` runes further on the same line
+func (p Pos) Advance(n int) Pos {
+	p.Offset += n
+	p.Column += n
+	return p
+}
+
+func (p Pos) Filename() string {
+	if p.File == nil {
+		return ""
+	}
+	return p.File.Name
+}
+
+func (p Pos) String() string {
+	if !p.IsValid() {
+		return "-"
+	}
+	if p.Filename() == "" {
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+	return fmt.Sprintf("%s:%d:%d", p.Filename(), p.Line, p.Column)
+}
@@ -1,5 +1,7 @@
 package token
 
+import "strings"
+
 type TokenType string
 
 type Token struct {
@@ -7,6 +9,44 @@ type Token struct {
 	Literal string
 	LineNo  int
 	Column  int
+	Offset  int
+	File    *File
+	// End is the position right after the token in the source,
+	// which the literal of strings and names may not match
+	End Pos
+}
+
+// Pos returns the position of the first character of the token
+func (t Token) Pos() Pos {
+	return Pos{File: t.File, Offset: t.Offset, Line: t.LineNo, Column: t.Column}
+}
+
+// Comment is a `# line` or `/* block */` comment in the source code;
+// Text includes the comment markers
+type Comment struct {
+	Text string
+	Pos  Pos
+}
+
+// EndLine is the line where the comment ends
+func (c Comment) EndLine() int {
+	return c.Pos.Line + strings.Count(c.Text, "\n")
+}
+
+// IsBlock reports whether the comment is a `/* block */` comment
+func (c Comment) IsBlock() bool {
+	return strings.HasPrefix(c.Text, "/*")
+}
+
+// Content returns the text of the comment without the comment markers
+func (c Comment) Content() string {
+	if c.IsBlock() {
+		return strings.TrimSpace(strings.TrimSuffix(strings.TrimPrefix(c.Text, "/*"), "*/"))
+	}
+	if strings.HasPrefix(c.Text, "//") {
+		return strings.TrimSpace(strings.TrimPrefix(c.Text, "//"))
+	}
+	return strings.TrimSpace(strings.TrimPrefix(c.Text, "#"))
 }
 
 const (
@@ -17,6 +57,8 @@ const (
 	PLUS    = "+"
 
 	STRING = "STRING"
+	// String with `{expr}` parts, the literal is the raw text
+	ISTRING = "ISTRING"
 	// Identifier token
 	IDENT = "IDENT"
 
@@ -27,6 +69,9 @@ const (
 
 	COLON = ":"
 
+	// Member access -> module.name
+	DOT = "."
+
 	// integer
 	INT = "INT"
 
@@ -43,9 +88,23 @@ const (
 	DIV    = "/"
 	MINUS  = "-"
 
+	MOD       = "%"
+	FLOOR_DIV = "~/"
+	POW       = "**"
+
+	//Compound assignment
+	PLUS_EQ  = "+="
+	MINUS_EQ = "-="
+	MUL_EQ   = "*="
+	DIV_EQ   = "/="
+
 	//Bang or `!`
 	EXC = "!"
 
+	//Logical operators
+	AND = "&&"
+	OR  = "||"
+
 	LT        = "<"
 	LTE       = "<="
 	GT        = ">"
@@ -70,6 +129,11 @@ const (
 	ELSE   = "ELSE"
 	RETURN = "RETURN"
     WHILE  = "WHILE"
+	FOREACH = "FOREACH"
+	IN      = "IN"
+	BREAK    = "BREAK"
+	CONTINUE = "CONTINUE"
+	IMPORT   = "IMPORT"
 )
 
 var HumanFriendly = map[string]string{
@@ -86,36 +150,73 @@ var HumanFriendly = map[string]string{
 	EKTI:   "ekti",
 	TAHOLE: "tahole",
     WHILE: "jotokhon",
+	FOREACH: "protiti",
+	IN:      "moddhe",
+	BREAK:    "thamo",
+	CONTINUE: "chalao",
+	IMPORT:   "ano",
 }
 
-var Keywords = map[string]TokenType{
-
-	"কাজ":    FUNC,
-	"kaj":    FUNC,
-	"fn":     FUNC,
-	"ধরি":    LET,
-	"dhori":  LET,
-	"let":    LET,
-	"সত্য":   TRUE,
-	"sotto":  TRUE,
-	"মিথ্যা": FALSE,
-	"mittha": FALSE,
-	"যদি":    IF,
-	"jodi":   IF,
-	"নাহলে":  ELSE,
-	"nahole": ELSE,
-	"ফেরাও":  RETURN,
-	"ferau":  RETURN,
-	"হল":     HOLO,
-	"holo":   HOLO,
-	"একটি":   EKTI,
-	"ekti":   EKTI,
-	"তাহলে":  TAHOLE,
-	"tahole": TAHOLE,
-    "jotokhon" : WHILE,
-    "while" : WHILE,
+// BaseKeywords are the spellings of the keywords in the default locale
+// packs, bn and roman, which the lexer knows before any are chosen
+var BaseKeywords = map[string]TokenType{
+
+	"কাজ":      FUNC,
+	"kaj":      FUNC,
+	"fn":       FUNC,
+	"ধরি":      LET,
+	"dhori":    LET,
+	"let":      LET,
+	"সত্য":     TRUE,
+	"sotto":    TRUE,
+	"মিথ্যা":   FALSE,
+	"mittha":   FALSE,
+	"যদি":      IF,
+	"jodi":     IF,
+	"নাহলে":    ELSE,
+	"nahole":   ELSE,
+	"ফেরাও":    RETURN,
+	"ferau":    RETURN,
+	"হল":       HOLO,
+	"holo":     HOLO,
+	"একটি":     EKTI,
+	"ekti":     EKTI,
+	"তাহলে":    TAHOLE,
+	"tahole":   TAHOLE,
+	"যতক্ষণ":   WHILE,
+	"jotokhon": WHILE,
+	"while":    WHILE,
+	"প্রতিটি":  FOREACH,
+	"protiti":  FOREACH,
+	"for":      FOREACH,
+	"মধ্যে":    IN,
+	"moddhe":   IN,
+	"in":       IN,
+	"থামো":     BREAK,
+	"thamo":    BREAK,
+	"break":    BREAK,
+	"চালাও":    CONTINUE,
+	"chalao":   CONTINUE,
+	"continue": CONTINUE,
+	"এবং":      AND,
+	"ebong":    AND,
+	"and":      AND,
+	"অথবা":     OR,
+	"othoba":   OR,
+	"or":       OR,
+	"আনো":      IMPORT,
+	"ano":      IMPORT,
+	"import":   IMPORT,
 }
 
+// Keywords maps every spelling of a keyword in use to its token
+// type; package locale replaces it when other packs are chosen
+var Keywords = BaseKeywords
+
+// BareFuncs are the spellings of FUNC which need no EKTI before
+// them, from the packs which have no word for EKTI
+var BareFuncs = map[string]bool{}
+
 func LookupIdent(ident string) TokenType {
 	if tok, ok := Keywords[ident]; ok {
 		return tok
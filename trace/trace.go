@@ -0,0 +1,150 @@
+// Package trace reports the steps of the interpreter.
+//
+// The lexer, the parser and the evaluator each have a logger which
+// is silent unless a program asks for it. Enable turns them on for
+// `vabna --trace=lexer,parser,eval`, writing one JSON event per
+// token, AST node or evaluation step; SetLogger puts any other
+// logrus logger in place of a stage's own.
+package trace
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"vabna/ast"
+	"vabna/object"
+	"vabna/token"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// Stage is a part of the interpreter whose steps can be traced
+type Stage string
+
+const (
+	LEXER  Stage = "lexer"
+	PARSER Stage = "parser"
+	EVAL   Stage = "eval"
+)
+
+var loggers = map[Stage]*log.Logger{
+	LEXER:  silent(),
+	PARSER: silent(),
+	EVAL:   silent(),
+}
+
+// silent returns a logger which writes nothing
+func silent() *log.Logger {
+	l := log.New()
+	l.Out = io.Discard
+	l.Level = log.PanicLevel
+	return l
+}
+
+// Stages returns the names of the stages, sorted
+func Stages() []string {
+	names := []string{}
+	for s := range loggers {
+		names = append(names, string(s))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Logger returns the logger of `stage`
+func Logger(stage Stage) *log.Logger {
+	return loggers[stage]
+}
+
+// SetLogger makes `l` the logger of `stage`; nil silences the stage
+func SetLogger(stage Stage, l *log.Logger) {
+	if l == nil {
+		l = silent()
+	}
+	loggers[stage] = l
+}
+
+// Enable writes the events of the stages in the comma separated
+// list `spec`, like `lexer,eval`, to `out` as JSON lines
+func Enable(spec string, out io.Writer) error {
+	for _, name := range strings.Split(spec, ",") {
+		stage := Stage(strings.TrimSpace(name))
+		if _, ok := loggers[stage]; !ok {
+			return fmt.Errorf("unknown stage `%s`", stage)
+		}
+
+		l := log.New()
+		l.Out = out
+		l.Level = log.DebugLevel
+		l.Formatter = &log.JSONFormatter{DisableTimestamp: true}
+		loggers[stage] = l
+	}
+	return nil
+}
+
+// On reports whether the events of `stage` are written anywhere
+func On(stage Stage) bool {
+	return loggers[stage].IsLevelEnabled(log.DebugLevel)
+}
+
+func event(stage Stage, msg string, fields log.Fields) {
+	fields["stage"] = string(stage)
+	loggers[stage].WithFields(fields).Debug(msg)
+}
+
+// Token reports a token the lexer read
+func Token(tk token.Token) {
+	if !On(LEXER) {
+		return
+	}
+	event(LEXER, "token", log.Fields{
+		"type":    string(tk.Type),
+		"literal": tk.Literal,
+		"pos":     tk.Pos().String(),
+	})
+}
+
+// Node reports an AST node the parser built
+func Node(node ast.Node) {
+	if !On(PARSER) || isNil(node) {
+		return
+	}
+	event(PARSER, "node", log.Fields{
+		"node": nodeName(node),
+		"pos":  node.Pos().String(),
+		"code": node.String(),
+	})
+}
+
+// Step reports the value `res` the evaluator got for `node`
+func Step(node ast.Node, res object.Obj) {
+	if !On(EVAL) || isNil(node) {
+		return
+	}
+	fields := log.Fields{
+		"node": nodeName(node),
+		"pos":  node.Pos().String(),
+	}
+	if res != nil {
+		fields["type"] = string(res.Type())
+		fields["value"] = res.Inspect()
+	}
+	event(EVAL, "step", fields)
+}
+
+// nodeName returns the name of the type of `node`, like InfixExpr
+func nodeName(node ast.Node) string {
+	return reflect.Indirect(reflect.ValueOf(node)).Type().Name()
+}
+
+// isNil reports whether `node` is nil or a nil pointer, which the
+// parser leaves behind for broken code
+func isNil(node ast.Node) bool {
+	if node == nil {
+		return true
+	}
+	v := reflect.ValueOf(node)
+	return v.Kind() == reflect.Ptr && v.IsNil()
+}
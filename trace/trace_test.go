@@ -0,0 +1,100 @@
+package trace_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"vabna/evaluator"
+	"vabna/lexer"
+	"vabna/object"
+	"vabna/parser"
+	"vabna/trace"
+)
+
+func run(src string) {
+	lx := lexer.NewLexer(src)
+	ps := parser.NewParser(&lx)
+	evaluator.Eval(ps.ParseProg(), object.NewEnv())
+}
+
+func TestTrace(t *testing.T) {
+	defer func() {
+		for _, s := range trace.Stages() {
+			trace.SetLogger(trace.Stage(s), nil)
+		}
+	}()
+
+	var out bytes.Buffer
+	if err := trace.Enable("parser, eval", &out); err != nil {
+		t.Fatal(err)
+	}
+	run("ধরি ক = 1 + 2;")
+
+	tests := []map[string]string{
+		{"stage": "parser", "msg": "node", "node": "NumberLit", "code": "1", "pos": "1:9"},
+		{"stage": "parser", "msg": "node", "node": "NumberLit", "code": "2", "pos": "1:13"},
+		{"stage": "parser", "msg": "node", "node": "InfixExpr", "code": "(1 + 2)", "pos": "1:9"},
+		{"stage": "parser", "msg": "node", "node": "LetStmt", "pos": "1:1"},
+		{"stage": "eval", "msg": "step", "node": "NumberLit", "value": "1"},
+		{"stage": "eval", "msg": "step", "node": "NumberLit", "value": "2"},
+		{"stage": "eval", "msg": "step", "node": "InfixExpr", "type": "NUM", "value": "3"},
+		{"stage": "eval", "msg": "step", "node": "LetStmt"},
+		{"stage": "eval", "msg": "step", "node": "Program"},
+	}
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	if len(lines) != len(tests) {
+		t.Fatalf("Expected %d events, Got=%d\n%s", len(tests), len(lines), out.String())
+	}
+
+	for i, tt := range tests {
+		ev := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(lines[i]), &ev); err != nil {
+			t.Fatalf("tests[%d] -> Not JSON %q", i, lines[i])
+		}
+		for k, v := range tt {
+			if ev[k] != v {
+				t.Fatalf("tests[%d] -> Expected %s=%q, Got=%v", i, k, v, ev[k])
+			}
+		}
+	}
+}
+
+func TestBroken(t *testing.T) {
+	defer trace.SetLogger(trace.PARSER, nil)
+
+	var out bytes.Buffer
+	if err := trace.Enable("parser", &out); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []string{"x = ", "h[1] +=", "ধরি ক = (1 + ;", "যদি (1 + ) তাহলে { 2 }", "[1, 2 +", "একটি কাজ(ক) { ক = }"}
+	for _, src := range tests {
+		out.Reset()
+		lx := lexer.NewLexer(src)
+		parser.NewParser(&lx).ParseProg()
+
+		for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+			if line != "" && !json.Valid([]byte(line)) {
+				t.Fatalf("%q -> Not JSON %q", src, line)
+			}
+		}
+	}
+}
+
+func TestSilent(t *testing.T) {
+	var out bytes.Buffer
+	trace.Enable("lexer", &out)
+	trace.SetLogger(trace.LEXER, nil)
+
+	run("দেখাও")
+	if out.Len() != 0 || trace.On(trace.LEXER) {
+		t.Fatalf("Expected nothing, Got=%q", out.String())
+	}
+
+	if err := trace.Enable("lexer,tokens", &out); err == nil {
+		t.Fatalf("Expected an error for `tokens`")
+	}
+	trace.SetLogger(trace.LEXER, nil)
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"vabna/errs"
+	"vabna/locale"
+)
+
+// translateCmd runs `vabna translate -to=bn|roman|en [-w] [files]`
+// and returns the exit code
+func translateCmd(args []string) int {
+	fs := flag.NewFlagSet("translate", flag.ContinueOnError)
+	to := fs.String("to", "", "")
+	write := fs.Bool("w", false, "")
+	parseFlags(fs, args)
+
+	pack, ok := locale.Find(*to)
+	if !ok {
+		complain(errs.CLI_BAD_SPELLING, *to)
+		return exitUsage
+	}
+
+	if fs.NArg() == 0 {
+		src, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			complain(errs.CLI_CANNOT_READ, "-", errs.Reason(err))
+			return exitUsage
+		}
+
+		out, ok := translateSource("<stdin>", string(src), "", pack)
+		if !ok {
+			return exitFailed
+		}
+		fmt.Print(out)
+		return exitOK
+	}
+
+	status := exitOK
+	for _, filename := range fs.Args() {
+		src, err := os.ReadFile(filename)
+		if err != nil {
+			complain(errs.CLI_CANNOT_READ, filename, errs.Reason(err))
+			status = exitUsage
+			continue
+		}
+
+		out, ok := translateSource(filename, string(src), filepath.Dir(filename), pack)
+		if !ok {
+			status = exitFailed
+			continue
+		}
+
+		if !*write {
+			fmt.Print(out)
+		} else if out != string(src) {
+			if err := os.WriteFile(filename, []byte(out), 0644); err != nil {
+				complain(errs.CLI_CANNOT_WRITE, filename, errs.Reason(err))
+				status = exitUsage
+			}
+		}
+	}
+
+	return status
+}
+
+// translateSource translates `src` to the spellings of `to`, reading it
+// with the locale of its `# locale:` comment if it has one. It warns if
+// that comment does not list `to`, as the result would not run.
+func translateSource(filename string, src string, dir string, to *locale.Pack) (string, bool) {
+	restore, err := usePragma(src, dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return "", false
+	}
+	defer restore()
+
+	out, err := locale.Translate(src, to)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", filename, err)
+		return "", false
+	}
+
+	if spec, ok := locale.Pragma(src); ok {
+		listed := false
+		for _, p := range locale.Active() {
+			listed = listed || p.Name == to.Name
+		}
+		if !listed {
+			complain(errs.CLI_NOT_IN_LOCALE, filename, spec, to.Name)
+		}
+	}
+
+	return out, true
+}
@@ -2,123 +2,280 @@ package main
 
 import (
 	"errors"
+	"flag"
 	"fmt"
+	"io"
 	"os"
-	"os/user"
-
-	/*
-		"vabna/evaluator"
-		"vabna/lexer"
-		"vabna/object"
-		"vabna/parser"
-	*/
+	"path/filepath"
+	"strings"
+
+	"vabna/errs"
 	"vabna/evaluator"
 	"vabna/lexer"
+	"vabna/locale"
+	"vabna/number"
 	"vabna/object"
 	"vabna/parser"
 	"vabna/repl"
+	"vabna/trace"
+)
 
-	log "github.com/sirupsen/logrus"
+// Exit codes of vabna
+const (
+	exitOK     = 0
+	exitFailed = 1 // the program stopped with an error; `fmt -check` found unformatted files
+	exitSyntax = 2 // the program has syntax errors
+	exitUsage  = 3 // bad options or commands, or files which cannot be read
 )
 
-func init() {
-	log.SetLevel(log.DebugLevel)
-	log.SetFormatter(&log.TextFormatter{
-		PadLevelText:  true,
-		FullTimestamp: true,
-	})
+// complain prints the message `key` of the catalogs to stderr
+func complain(key string, a ...interface{}) {
+	fmt.Fprintln(os.Stderr, errs.Format(key, a...))
+}
+
+// usePragma puts the packs of the `# locale:` comment of `src` in
+// use; the function it returns puts the packs used before back
+func usePragma(src string, dir string) (func(), error) {
+	prev := locale.Active()
+	restore := func() { locale.Use(prev...) }
 
-	log.SetOutput(os.Stdout)
+	spec, ok := locale.Pragma(src)
+	if !ok {
+		return restore, nil
+	}
+	if err := locale.Select(spec, dir); err != nil {
+		return restore, errors.New(errs.Format(errs.CLI_BAD_LOCALE, spec, err))
+	}
+	return restore, nil
 }
 
-func main() {
-	/*
-	   	examplecode := `
-	           let newAdder = fn(x) { fn(y) {x+y} };
-	           let addTwo = newAdder(2);
-	           addTwo
+// flagHelp are the keys of the catalogs with the help of every flag
+var flagHelp = map[string]string{
+	"numerals": errs.CLI_FLAG_NUMERALS,
+	"lang":     errs.CLI_FLAG_LANG,
+	"locale":   errs.CLI_FLAG_LOCALE,
+	"e":        errs.CLI_FLAG_CODE,
+	"trace":    errs.CLI_FLAG_TRACE,
+	"check":    errs.CLI_FLAG_CHECK,
+	"w":        errs.CLI_FLAG_WRITE,
+	"keywords": errs.CLI_FLAG_KEYWORDS,
+	"to":       errs.CLI_FLAG_TO,
+}
+
+// printDefaults prints the flags of `fs` with their help in errs.Lang
+func printDefaults(fs *flag.FlagSet) {
+	fs.VisitAll(func(f *flag.Flag) { f.Usage = errs.Msg(flagHelp[f.Name]) })
+	fs.PrintDefaults()
+}
 
-	       `
+// parseFlags parses `args` with `fs`, exiting with exitUsage for bad flags
+func parseFlags(fs *flag.FlagSet, args []string) {
+	fs.Init(fs.Name(), flag.ContinueOnError)
+	if fs != flag.CommandLine {
+		fs.Usage = func() {
+			fmt.Fprintln(fs.Output(), errs.Format(errs.CLI_CMD_OPTIONS, fs.Name()))
+			printDefaults(fs)
+		}
+	}
+	if err := fs.Parse(args); err == flag.ErrHelp {
+		os.Exit(exitOK)
+	} else if err != nil {
+		os.Exit(exitUsage)
+	}
+}
 
-	   	l := lexer.NewLexer(examplecode)
-	   	p := parser.NewParser(&l)
-	   	env := object.NewEnv()
-	   	e := evaluator.Eval(p.ParseProg(), env)
-	   	fmt.Println(e)
-	   	//fmt.Printf("AST:\n%v\n", p.ParseProg().ToString())
+func main() {
+	// the help of the flags is filled in by printDefaults
+	numerals := flag.String("numerals", "en", "")
+	lang := flag.String("lang", errs.Lang, "")
+	packs := flag.String("locale", strings.Join(locale.DefaultPacks, ","), "")
+	code := flag.String("e", "", "")
+	stages := flag.String("trace", "", "")
 
-	   	if len(p.GetErrors()) > 0 {
-	   		var errs string
+	flag.Usage = func() {
+		// `-lang=en -h` shows the help in English
+		errs.SetLang(*lang)
+		fmt.Fprintln(flag.CommandLine.Output(), errs.Msg(errs.CLI_USAGE))
+		printDefaults(flag.CommandLine)
+	}
+	parseFlags(flag.CommandLine, os.Args[1:])
 
-	   		for _, err := range p.GetErrors() {
-	   			errs += fmt.Sprintf("%s\n", err)
-	   		}
+	if err := errs.SetLang(*lang); err != nil {
+		complain(errs.CLI_BAD_LANG, *lang, strings.Join(errs.Langs(), ", "))
+		os.Exit(exitUsage)
+	}
 
-	   		log.Warnln(errs)
-	   	}
-	*/
+	if err := locale.Select(*packs, ""); err != nil {
+		complain(errs.CLI_BAD_LOCALE, *packs, err)
+		os.Exit(exitUsage)
+	}
 
-	//var name string = "পলাশ"
-	//fmt.Println(len(name))
-	//fmt.Println(string([]rune(name)[3]))
+	if *stages != "" {
+		if err := trace.Enable(*stages, os.Stderr); err != nil {
+			complain(errs.CLI_BAD_TRACE, *stages, strings.Join(trace.Stages(), ", "))
+			os.Exit(exitUsage)
+		}
+	}
 
-	//fmt.Println(name[12])
+	switch *numerals {
+	case "bn":
+		number.OutputNumerals = number.BengaliNumerals
+	case "en":
+		number.OutputNumerals = number.EnglishNumerals
+	default:
+		complain(errs.CLI_BAD_NUMERALS, *numerals)
+		os.Exit(exitUsage)
+	}
 
-	args := os.Args[1:]
+	args := flag.Args()
 
-	if len(args) >= 1 {
-		filename := args[0]
-		_, err := os.Stat(filename)
+	inline := false
+	flag.Visit(func(f *flag.Flag) { inline = inline || f.Name == "e" })
+	if inline {
+		os.Exit(runSource("-e", *code, "", args, true))
+	}
 
-		if errors.Is(err, os.ErrNotExist) {
-			log.Fatalf("File `%s` does not exist!", filename)
+	if len(args) == 0 {
+		if isTerminal(os.Stdin) {
+			repl.Repl(os.Stdin, os.Stdout)
+			return
 		}
+		os.Exit(runFile("-", nil))
+	}
 
-		f, err := os.ReadFile(filename)
-
-		if err != nil {
-			log.Fatalf("Cannot read `%s`", filename)
+	switch args[0] {
+	case "run":
+		if len(args) < 2 {
+			complain(errs.CLI_NEEDS_FILE, "run")
+			os.Exit(exitUsage)
 		}
+		os.Exit(runFile(args[1], args[2:]))
+	case "repl":
+		repl.Repl(os.Stdin, os.Stdout)
+	case "check":
+		os.Exit(checkCmd(args[1:]))
+	case "fmt":
+		os.Exit(formatCmd(args[1:]))
+	case "translate":
+		os.Exit(translateCmd(args[1:]))
+	default:
+		// `vabna file.vab` is short for `vabna run file.vab`
+		if _, err := os.Stat(args[0]); err != nil && filepath.Ext(args[0]) == "" {
+			complain(errs.CLI_UNKNOWN_CMD, args[0])
+			os.Exit(exitUsage)
+		}
+		os.Exit(runFile(args[0], args[1:]))
+	}
+}
 
-		//fmt.Println(string(f))
+// isTerminal reports whether `f` is a terminal rather than a pipe or a file
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
 
-		lx := lexer.NewLexer(string(f))
-		ps := parser.NewParser(&lx)
-		at := ps.ParseProg()
+// readSource reads the file `filename`, or stdin if it is `-`
+func readSource(filename string) (string, error) {
+	var src []byte
+	var err error
+	if filename == "-" {
+		src, err = io.ReadAll(os.Stdin)
+	} else {
+		src, err = os.ReadFile(filename)
+	}
+	return string(src), err
+}
 
-		if len(ps.GetErrors()) != 0 {
-			repl.ShowParseErrors(os.Stdin, ps.GetErrors())
-			log.Fatalf("fix above mentioned errors first!")
-		}
-		env := object.NewEnv()
-		evd := evaluator.Eval(at, env)
+// runFile runs the program in the file `filename`, or the one on
+// stdin if it is `-`, with the arguments `args`
+func runFile(filename string, args []string) int {
+	src, err := readSource(filename)
+	if err != nil {
+		complain(errs.CLI_CANNOT_READ, filename, errs.Reason(err))
+		return exitUsage
+	}
 
-		if evd != nil {
-			fmt.Println(evd.Inspect())
-		}
+	if filename == "-" {
+		return runSource("<stdin>", src, "", args, false)
+	}
+	return runSource(filename, src, filepath.Dir(filename), args, false)
+}
+
+// runSource runs the program `src` named `name` and returns the exit
+// code; with `show` the value of the program is printed, unless null
+func runSource(name string, src string, dir string, args []string, show bool) int {
+	restore, err := usePragma(src, dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitUsage
+	}
+	defer restore()
 
-		//fmt.Println(args[0])
+	lx := lexer.NewFileLexer(name, src)
+	ps := parser.NewParser(&lx)
+	prog := ps.ParseProg()
 
+	if len(ps.GetErrors()) != 0 {
+		repl.ShowParseErrors(os.Stderr, ps.GetErrors())
+		return exitSyntax
 	}
 
-	startRepl := true
+	evaluator.ScriptArgs = args
+	res := evaluator.Eval(prog, object.NewEnv())
 
-	////
-	//var name = "Palash Bauri"
-	//var age = 20
-	//fmt.Println(age + 2001)
-	////
+	if e, ok := res.(*object.Error); ok {
+		fmt.Fprintln(os.Stderr, e.Inspect())
+		return exitFailed
+	}
+	if show && res != nil && res != evaluator.NULL {
+		fmt.Println(res.Inspect())
+	}
+	return exitOK
+}
 
-	if startRepl {
-		user, err := user.Current()
+// checkCmd runs `vabna check [files]`, which reports the syntax
+// errors of the files without running them
+func checkCmd(files []string) int {
+	if len(files) == 0 {
+		files = []string{"-"}
+	}
 
+	status := exitOK
+	for _, filename := range files {
+		src, err := readSource(filename)
 		if err != nil {
-			panic(err)
+			complain(errs.CLI_CANNOT_READ, filename, errs.Reason(err))
+			status = exitUsage
+			continue
+		}
+
+		name, dir := filename, filepath.Dir(filename)
+		if filename == "-" {
+			name, dir = "<stdin>", ""
 		}
+		if !checkSource(name, src, dir) && status == exitOK {
+			status = exitSyntax
+		}
+	}
 
-		fmt.Printf("Hey, %s\n", user.Username)
+	return status
+}
 
-		repl.Repl(os.Stdin, os.Stdout)
+func checkSource(name string, src string, dir string) bool {
+	restore, err := usePragma(src, dir)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return false
 	}
+	defer restore()
+
+	lx := lexer.NewFileLexer(name, src)
+	ps := parser.NewParser(&lx)
+	ps.ParseProg()
 
+	if len(ps.GetErrors()) != 0 {
+		repl.ShowParseErrors(os.Stderr, ps.GetErrors())
+		return false
+	}
+	return true
 }